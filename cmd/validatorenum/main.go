@@ -0,0 +1,163 @@
+// Command validatorenum generates validator.RegisterEnum calls for the
+// constants of named types, so that the enum rule stays in sync with
+// their const blocks.
+//
+// Usage:
+//
+//	validatorenum -type Status,Role [-output file] [dir]
+//
+// It type-checks the Go package in dir, the current directory by default,
+// collects every package-level constant of each named type and writes an
+// init function registering them to dir/validator_enum.go. It is meant to
+// be run by go:generate:
+//
+//	//go:generate validatorenum -type Status
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const defaultOutput = "validator_enum.go"
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+	output    = flag.String("output", "", "output file name; default dir/"+defaultOutput)
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: validatorenum -type Status,Role [-output file] [dir]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = filepath.Join(dir, defaultOutput)
+	}
+	pkg, typeErr := loadPackage(dir, outputPath)
+	if pkg == nil {
+		fmt.Fprintln(os.Stderr, typeErr)
+		os.Exit(1)
+	}
+	src, err := generate(pkg, strings.Split(*typeNames, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if typeErr != nil {
+			fmt.Fprintln(os.Stderr, typeErr)
+		}
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// loadPackage type-checks the non-test Go files in dir that match the
+// build constraints of the default build context, leaving out the
+// previously generated output file. Type errors do not keep constants
+// from being resolved, so the package is returned even if it has some,
+// together with the first one; it is nil only if dir cannot be parsed.
+func loadPackage(dir, outputPath string) (*types.Package, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(paths))
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Clean(path) == filepath.Clean(outputPath) {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, filepath.Base(path)); err != nil {
+			return nil, err
+		} else if !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Keep checking after the first error.
+		Error: func(error) {},
+	}
+	return conf.Check(files[0].Name.Name, fset, files, nil)
+}
+
+// generate returns the source of a file registering the constants of each
+// of typeNames in pkg.
+func generate(pkg *types.Package, typeNames []string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by validatorenum -type %s; DO NOT EDIT.\n\n", strings.Join(typeNames, ","))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(&buf, "import \"github.com/GeorgyMironov2001/validator\"\n\n")
+	fmt.Fprintf(&buf, "func init() {\n")
+	for _, typeName := range typeNames {
+		names, err := constantsOf(pkg, typeName)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "\tvalidator.RegisterEnum(\n")
+		for _, name := range names {
+			fmt.Fprintf(&buf, "\t\t%s,\n", name)
+		}
+		fmt.Fprintf(&buf, "\t)\n")
+	}
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
+
+// constantsOf returns the names of the package-level constants of the
+// named type typeName in pkg, in source order.
+func constantsOf(pkg *types.Package, typeName string) ([]string, error) {
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.Name())
+	}
+	consts := make([]*types.Const, 0)
+	for _, name := range pkg.Scope().Names() {
+		if c, ok := pkg.Scope().Lookup(name).(*types.Const); ok && types.Identical(c.Type(), obj.Type()) && c.Name() != "_" {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("no constants of type %s found", typeName)
+	}
+	slices.SortFunc(consts, func(a, b *types.Const) int {
+		return int(a.Pos() - b.Pos())
+	})
+	names := make([]string, len(consts))
+	for i, c := range consts {
+		names[i] = c.Name()
+	}
+	return names, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"status.go": "package blog\n\n" +
+			"type Status string\n\n" +
+			"const (\n" +
+			"\tStatusDraft Status = \"draft\"\n" +
+			"\t_ Status = \"unused\"\n" +
+			"\tStatusPublished Status = \"published\"\n" +
+			")\n\n" +
+			"type Role int\n\n" +
+			"const (\n" +
+			"\tRoleUser Role = iota\n" +
+			"\tRoleAdmin\n" +
+			")\n\n" +
+			"const Other = \"x\"\n",
+		"archived.go": "package blog\n\n" +
+			"const StatusArchived Status = \"archived\"\n",
+		"ignored.go": "//go:build ignore\n\n" +
+			"package blog\n\n" +
+			"const StatusDraft Status = \"other\"\n",
+		"other_plan9.go": "package blog\n\n" +
+			"const StatusPlan9 Status = \"plan9\"\n",
+		"status_test.go": "package blog\n\n" +
+			"const StatusTest Status = \"test\"\n",
+		defaultOutput: "package blog\n\n" +
+			"func init() { undefined() }\n",
+		"broken.go": "package blog\n\n" +
+			"var broken int = \"not an int\"\n",
+	})
+	pkg, typeErr := loadPackage(dir, filepath.Join(dir, defaultOutput))
+	if pkg == nil {
+		t.Fatal(typeErr)
+	}
+	if typeErr == nil || !strings.Contains(typeErr.Error(), "broken.go") {
+		t.Fatalf("got type error %v, want the one in broken.go", typeErr)
+	}
+	src, err := generate(pkg, []string{"Status", "Role"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by validatorenum -type Status,Role; DO NOT EDIT.\n\n" +
+		"package blog\n\n" +
+		"import \"github.com/GeorgyMironov2001/validator\"\n\n" +
+		"func init() {\n" +
+		"\tvalidator.RegisterEnum(\n" +
+		"\t\tStatusArchived,\n" +
+		"\t\tStatusDraft,\n" +
+		"\t\tStatusPublished,\n" +
+		"\t)\n" +
+		"\tvalidator.RegisterEnum(\n" +
+		"\t\tRoleUser,\n" +
+		"\t\tRoleAdmin,\n" +
+		"\t)\n" +
+		"}\n"
+	if string(src) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", src, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.go": "package a\n\ntype Empty int\n",
+	})
+	pkg, err := loadPackage(dir, filepath.Join(dir, defaultOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := generate(pkg, []string{"Missing"}); err == nil || !strings.Contains(err.Error(), "type Missing not found") {
+		t.Fatalf("got %v", err)
+	}
+	if _, err := generate(pkg, []string{"Empty"}); err == nil || !strings.Contains(err.Error(), "no constants of type Empty") {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestLoadPackageNoFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"x_test.go": "package x\n",
+	})
+	if pkg, err := loadPackage(dir, filepath.Join(dir, defaultOutput)); pkg != nil || err == nil {
+		t.Fatalf("got %v, %v; want an error", pkg, err)
+	}
+}
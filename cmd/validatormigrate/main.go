@@ -0,0 +1,257 @@
+// Command validatormigrate rewrites `validate` struct tags in Go source
+// files into the current tag grammar of the validator package.
+//
+// Usage:
+//
+//	validatormigrate [-from auto|native|playground] [-w] path...
+//
+// Each path is a Go file or a directory that is walked for Go files.
+// Tags written for github.com/go-playground/validator, such as
+// `validate:"required,min=3,oneof=a b"`, become `validate:"required|min:3|in:a,b"`;
+// tags in the old single-rule name:value form are checked and kept.
+// Without -w the rewritten files are printed to standard output. Tags that
+// cannot be translated are left untouched and reported on standard error,
+// and the command then exits with status 1. So are go-playground tags with
+// len, min or max on a field whose type is not a predeclared scalar, as
+// these may check the length of a slice, and struct tags that are not in
+// the conventional key:"value" format.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+var (
+	from  = flag.String("from", "auto", "syntax of the existing tags: auto, native or playground")
+	write = flag.Bool("w", false, "write result to the source files instead of standard output")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: validatormigrate [-from auto|native|playground] [-w] path...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (*from != "auto" && *from != "native" && *from != "playground") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	failed := false
+	for _, root := range flag.Args() {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			problems, err := migrateFile(path)
+			if err != nil {
+				return err
+			}
+			for _, problem := range problems {
+				fmt.Fprintln(os.Stderr, problem)
+			}
+			failed = failed || len(problems) > 0
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// migrateFile rewrites the validate tags of the file at path and returns a
+// description of every tag it could not translate.
+func migrateFile(path string) ([]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]string, 0)
+	changed := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		field, ok := n.(*ast.Field)
+		if !ok || field.Tag == nil {
+			return true
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return true
+		}
+		value, ok := lookupTag(tag, "validate")
+		if !ok {
+			return true
+		}
+		migrated, err := migrateTag(value, field.Type)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s %q: %s", fset.Position(field.Tag.Pos()), fieldName(field), value, err))
+			return true
+		}
+		if migrated == value {
+			return true
+		}
+		replaced, err := replaceTag(tag, "validate", migrated)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s: %s", fset.Position(field.Tag.Pos()), fieldName(field), err))
+			return true
+		}
+		field.Tag.Value = "`" + replaced + "`"
+		changed = true
+		return true
+	})
+	if !changed && *write {
+		return problems, nil
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	if !*write {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return problems, err
+	}
+	return problems, os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// migrateTag translates a validate tag value into the native grammar.
+// typ is the type of the field the tag belongs to.
+func migrateTag(value string, typ ast.Expr) (string, error) {
+	syntax := validator.SyntaxPlayground
+	switch *from {
+	case "native":
+		syntax = validator.SyntaxNative
+	case "auto":
+		if _, err := validator.TranslateTag(value, validator.SyntaxNative); err == nil {
+			syntax = validator.SyntaxNative
+		}
+	}
+	if syntax == validator.SyntaxPlayground && !isScalar(typ) {
+		for _, rule := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(rule, "=")
+			if name == "dive" {
+				break
+			}
+			if name != "len" && name != "min" && name != "max" {
+				continue
+			}
+			if array, ok := typ.(*ast.ArrayType); ok && array.Len == nil {
+				return "", fmt.Errorf("%s on a slice checks its length, which has no equivalent", name)
+			}
+			return "", fmt.Errorf("%s on type %s may check a length, which has no equivalent; review it by hand", name, types.ExprString(typ))
+		}
+	}
+	return validator.TranslateTag(value, syntax)
+}
+
+// scalarTypes are the predeclared types on which len, min and max check the
+// value itself in both grammars.
+var scalarTypes = map[string]bool{
+	"string": true, "bool": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// isScalar reports whether typ is a predeclared scalar type or a pointer to
+// one. Named types are not, as their underlying type is not known here.
+func isScalar(typ ast.Expr) bool {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	return ok && scalarTypes[ident.Name]
+}
+
+func fieldName(field *ast.Field) string {
+	if len(field.Names) == 0 {
+		return "embedded field"
+	}
+	return "field " + field.Names[0].Name
+}
+
+// lookupTag is reflect.StructTag.Lookup.
+func lookupTag(tag, key string) (string, bool) {
+	pairs, _ := splitTag(tag)
+	for _, kv := range pairs {
+		if kv.key == key {
+			value, err := strconv.Unquote(kv.value)
+			return value, err == nil
+		}
+	}
+	return "", false
+}
+
+// replaceTag returns tag with the value for key set to value, keeping the
+// other keys and their order. It fails if part of tag is not in the
+// conventional format, which it could not keep.
+func replaceTag(tag, key, value string) (string, error) {
+	pairs, rest := splitTag(tag)
+	if rest != "" {
+		return "", fmt.Errorf("cannot parse struct tag at %q", rest)
+	}
+	res := make([]string, len(pairs))
+	for i, kv := range pairs {
+		if kv.key == key {
+			kv.value = strconv.Quote(value)
+		}
+		res[i] = kv.key + ":" + kv.value
+	}
+	return strings.Join(res, " "), nil
+}
+
+type tagPair struct {
+	key   string
+	value string // still quoted
+}
+
+// splitTag splits a struct tag into its key:"value" pairs following the
+// conventional format described in reflect.StructTag, and returns the rest
+// of tag from the first part that is not in that format.
+func splitTag(tag string) ([]tagPair, string) {
+	res := make([]tagPair, 0)
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return res, ""
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return res, tag
+		}
+		j := i + 2
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(tag) {
+			return res, tag
+		}
+		res = append(res, tagPair{key: tag[:i], value: tag[i+1 : j+1]})
+		tag = tag[j+1:]
+	}
+}
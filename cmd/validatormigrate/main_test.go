@@ -0,0 +1,144 @@
+package main
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setFlag(t *testing.T, p *string, value string) {
+	old := *p
+	*p = value
+	t.Cleanup(func() { *p = old })
+}
+
+func TestMigrateTag(t *testing.T) {
+	slice := &ast.ArrayType{Elt: ast.NewIdent("string")}
+	array := &ast.ArrayType{Len: &ast.BasicLit{Value: "2"}, Elt: ast.NewIdent("string")}
+	scalar := ast.NewIdent("string")
+	pointer := &ast.StarExpr{X: ast.NewIdent("int")}
+	named := ast.NewIdent("Tags")
+	slicePointer := &ast.StarExpr{X: slice}
+	imported := &ast.SelectorExpr{X: ast.NewIdent("time"), Sel: ast.NewIdent("Duration")}
+	tests := []struct {
+		from    string
+		value   string
+		typ     ast.Expr
+		want    string
+		wantErr string
+	}{
+		{from: "auto", value: "required,min=3,oneof=a b", typ: scalar, want: "required|min:3|in:a,b"},
+		{from: "auto", value: "len:5", typ: scalar, want: "len:5"},
+		{from: "auto", value: "required|min:3", typ: scalar, want: "required|min:3"},
+		{from: "native", value: "min:3", typ: scalar, want: "min:3"},
+		{from: "native", value: "min=3", typ: scalar, wantErr: "invalid validator syntax"},
+		{from: "playground", value: "required", typ: scalar, want: "required"},
+		{from: "playground", value: "dive,min=2", typ: slice, want: "dive|min:2"},
+		{from: "auto", value: "min=1,dive,len=2", typ: slice, wantErr: "min on a slice"},
+		{from: "auto", value: "max=2", typ: array, wantErr: "max on type [2]string may check a length"},
+		{from: "auto", value: "min=1", typ: pointer, want: "min:1"},
+		{from: "auto", value: "required,min=1", typ: named, wantErr: "min on type Tags may check a length"},
+		{from: "auto", value: "min=1", typ: slicePointer, wantErr: "min on type *[]string may check a length"},
+		{from: "auto", value: "max=5", typ: imported, wantErr: "max on type time.Duration"},
+		{from: "auto", value: "required,dive,min=1", typ: named, want: "required|dive|min:1"},
+		{from: "auto", value: "required", typ: named, want: "required"},
+		{from: "native", value: "min:1", typ: named, want: "min:1"},
+		{from: "auto", value: "required,uuid", typ: scalar, wantErr: `unsupported tag "uuid"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"/"+tt.value, func(t *testing.T) {
+			setFlag(t, from, tt.from)
+			got, err := migrateTag(tt.value, tt.typ)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got %q, %v; want error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceTag(t *testing.T) {
+	tag := `json:"name,omitempty" validate:"required,min=3" db:"name"`
+	if got, ok := lookupTag(tag, "validate"); !ok || got != "required,min=3" {
+		t.Fatalf("lookupTag: got %q, %v", got, ok)
+	}
+	if _, ok := lookupTag(tag, "xml"); ok {
+		t.Fatal("lookupTag found a missing key")
+	}
+	want := `json:"name,omitempty" validate:"required|min:3" db:"name"`
+	if got, err := replaceTag(tag, "validate", "required|min:3"); err != nil || got != want {
+		t.Fatalf("replaceTag: got %s, %v; want %s", got, err, want)
+	}
+}
+
+func TestReplaceTagMalformed(t *testing.T) {
+	for _, tag := range []string{
+		`validate:"min=3" db:name`,
+		`validate:"min=3" json:"name`,
+		`validate:"min=3" :"x"`,
+	} {
+		if got, err := replaceTag(tag, "validate", "min:3"); err == nil {
+			t.Errorf("replaceTag(%s) = %s, want an error", tag, got)
+		}
+	}
+}
+
+func TestSplitTagEscapes(t *testing.T) {
+	pairs, rest := splitTag(`a:"x\"y" b:"z" `)
+	if len(pairs) != 2 || pairs[0].value != `"x\"y"` || pairs[1].key != "b" || rest != "" {
+		t.Fatalf("got %+v, %q", pairs, rest)
+	}
+	if pairs, rest := splitTag(`a:"x" b`); len(pairs) != 1 || rest != "b" {
+		t.Fatalf("got %+v, %q", pairs, rest)
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	setFlag(t, from, "auto")
+	old := *write
+	*write = true
+	t.Cleanup(func() { *write = old })
+
+	src := "package p\n\n" +
+		"type User struct {\n" +
+		"\tName string `json:\"name\" validate:\"required,min=3\"`\n" +
+		"\tRole string `validate:\"in:a,b\"`\n" +
+		"\tID   string `validate:\"uuid\"`\n" +
+		"\tTags Tags   `validate:\"min=1\"`\n" +
+		"\tNote string `validate:\"max=9\" db:note`\n" +
+		"}\n"
+	path := filepath.Join(t.TempDir(), "user.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := migrateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 3 || !strings.Contains(problems[0], `field ID "uuid"`) ||
+		!strings.Contains(problems[1], `field Tags "min=1"`) || !strings.Contains(problems[2], "field Note: cannot parse struct tag") {
+		t.Fatalf("problems: %q", problems)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package p\n\n" +
+		"type User struct {\n" +
+		"\tName string `json:\"name\" validate:\"required|min:3\"`\n" +
+		"\tRole string `validate:\"in:a,b\"`\n" +
+		"\tID   string `validate:\"uuid\"`\n" +
+		"\tTags Tags   `validate:\"min=1\"`\n" +
+		"\tNote string `validate:\"max=9\" db:note`\n" +
+		"}\n"
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
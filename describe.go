@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Rule is a single validator declared in a `validate` struct tag,
+// e.g. Name "len" and Param "5" for `validate:"len:5"`.
+type Rule struct {
+	Name  string
+	Param string
+}
+
+// FieldRules lists the rules declared on one field of a struct type.
+type FieldRules struct {
+	// Path is the dotted path to the field, as reported by ValidationError.Path.
+	// The fields of the elements of a slice or array of structs are listed
+	// once for all elements, with "[]" in place of the index, e.g.
+	// "Addrs[].Zip".
+	Path string
+	// Index is the index sequence for reflect.Value.FieldByIndex, with -1
+	// where Path has "[]".
+	Index []int
+	Type  reflect.Type
+	// Rules are the rules of the field type followed by the ones in its
+	// tag, in tag order, except that a leading omitempty stays first.
+	// On a slice, rules following "dive" apply to its elements.
+	Rules []Rule
+}
+
+// Describe returns the rules declared on the fields of struct type t and
+// of its nested structs, including the structs held in slices and arrays,
+// in declaration order and translated to this library's rules when
+// another TagSyntax is selected. Rules of the field types, see
+// RegisterTypeRules, are merged in. Variables in parameters are resolved
+// when WithParams is given and left as written otherwise. Tags that
+// Validate would reject are reported in the returned error.
+func Describe(t reflect.Type, opts ...Option) ([]FieldRules, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, NewValidationError(ErrNotStruct, "")
+	}
+	o := newOptions(opts)
+	res := make([]FieldRules, 0)
+	resErrors := make(Errors, 0)
+	describeType(t, "", nil, &o, &res, &resErrors)
+	if len(resErrors) > 0 {
+		return res, resErrors
+	}
+	return res, nil
+}
+
+func describeType(t reflect.Type, path string, index []int, o *options, res *[]FieldRules, resErrors *Errors) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldPath := joinPath(path, field.Name)
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if field.Type.Kind() == reflect.Struct {
+			describeType(field.Type, fieldPath, fieldIndex, o, res, resErrors)
+			continue
+		}
+		if (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array) && field.Type.Elem().Kind() == reflect.Struct {
+			describeType(field.Type.Elem(), fieldPath+"[]", append(fieldIndex[:len(fieldIndex):len(fieldIndex)], -1), o, res, resErrors)
+		}
+		tag, ok := field.Tag.Lookup("validate")
+		if !field.IsExported() {
+			if ok {
+				resErrors.add(NewValidationError(ErrValidateForUnexportedFields, field.Name), fieldPath, "")
+			}
+			continue
+		}
+		tagRules := parseRules(field.Name, tag, o.syntax, fieldPath, resErrors)
+		rules := make([]Rule, 0, len(tagRules))
+		if len(tagRules) > 0 && tagRules[0].Name == "omitempty" {
+			rules = append(rules, tagRules[0])
+			tagRules = tagRules[1:]
+		}
+		rules = append(rules, parseRules(field.Name, typeRules(field.Type), SyntaxNative, fieldPath, resErrors)...)
+		rules = append(rules, tagRules...)
+		if field.Type.Kind() == reflect.Slice {
+			if elemRules := parseRules(field.Name, typeRules(field.Type.Elem()), SyntaxNative, fieldPath, resErrors); len(elemRules) > 0 {
+				if !slices.Contains(rules, Rule{Name: "dive"}) {
+					rules = append(rules, Rule{Name: "dive"})
+				}
+				rules = append(rules, elemRules...)
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		if o.params != nil {
+			rules = resolveParams(field.Name, rules, fieldPath, o, resErrors)
+		}
+		*res = append(*res, FieldRules{
+			Path:  fieldPath,
+			Index: fieldIndex,
+			Type:  field.Type,
+			Rules: rules,
+		})
+	}
+}
+
+// parseRules returns the rules in tag, reporting a syntax error in it to
+// resErrors.
+func parseRules(fieldName, tag string, syntax TagSyntax, path string, resErrors *Errors) []Rule {
+	rules := make([]Rule, 0)
+	for tag != "" {
+		var rule Rule
+		var err error
+		if rule, tag, err = nextRule(fieldName, tag, syntax); err != nil {
+			resErrors.add(err, path, "")
+			break
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// resolveParams returns rules with the variables in their parameters
+// resolved, reporting undefined variables to resErrors.
+func resolveParams(fieldName string, rules []Rule, path string, o *options, resErrors *Errors) []Rule {
+	for i, rule := range rules {
+		var err error
+		if rules[i], err = resolveParam(fieldName, rule, o); err != nil {
+			resErrors.add(err, path, rule.Name)
+		}
+	}
+	return rules
+}
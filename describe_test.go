@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type describeLine struct {
+	Text string `validate:"required"`
+}
+
+type describeAddress struct {
+	Zip   string `validate:"len:5"`
+	Lines [2]describeLine
+}
+
+type describeUser struct {
+	Name   string `validate:"min:3"`
+	Home   describeAddress
+	Addrs  []describeAddress `validate:"required"`
+	Ptrs   []*describeAddress
+	hidden string
+}
+
+func TestDescribe(t *testing.T) {
+	fields, err := Describe(reflect.TypeFor[describeUser]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FieldRules{
+		{Path: "Name", Index: []int{0}, Rules: []Rule{{Name: "min", Param: "3"}}},
+		{Path: "Home.Zip", Index: []int{1, 0}, Rules: []Rule{{Name: "len", Param: "5"}}},
+		{Path: "Home.Lines[].Text", Index: []int{1, 1, -1, 0}, Rules: []Rule{{Name: "required"}}},
+		{Path: "Addrs[].Zip", Index: []int{2, -1, 0}, Rules: []Rule{{Name: "len", Param: "5"}}},
+		{Path: "Addrs[].Lines[].Text", Index: []int{2, -1, 1, -1, 0}, Rules: []Rule{{Name: "required"}}},
+		{Path: "Addrs", Index: []int{2}, Rules: []Rule{{Name: "required"}}},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d: %+v", len(fields), len(want), fields)
+	}
+	for i, field := range fields {
+		field.Type = nil
+		if !reflect.DeepEqual(field, want[i]) {
+			t.Errorf("field %d: got %+v, want %+v", i, field, want[i])
+		}
+	}
+}
+
+func TestDescribeErrors(t *testing.T) {
+	type bad struct {
+		Name  string `validate:"min"`
+		Items []struct {
+			Code string `validate:"len:x"`
+		}
+		secret string `validate:"required"`
+	}
+	_ = bad{}.secret
+	fields, err := Describe(reflect.TypeFor[bad]())
+	want := "Name: invalid validator syntax\n" +
+		"Items[].Code: invalid validator syntax\n" +
+		"secret: validation for unexported field is not allowed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	if len(fields) != 0 {
+		t.Fatalf("got fields %+v", fields)
+	}
+	if _, err := Describe(reflect.TypeFor[string]()); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+}
+
+func TestValidateSliceOfStructs(t *testing.T) {
+	v := describeUser{
+		Name: "alice",
+		Home: describeAddress{Zip: "12345", Lines: [2]describeLine{{"a"}, {}}},
+		Addrs: []describeAddress{
+			{Zip: "12345", Lines: [2]describeLine{{"a"}, {"b"}}},
+			{Zip: "1", Lines: [2]describeLine{{"a"}, {}}},
+		},
+		Ptrs: []*describeAddress{{Zip: "1"}},
+	}
+	want := "Home.Lines[1].Text: required validation failed\n" +
+		"Addrs[1].Zip: len validation failed\n" +
+		"Addrs[1].Lines[1].Text: required validation failed"
+	if err := Validate(v); err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
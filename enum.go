@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// EnumValue is the set of types whose values can be registered with
+// RegisterEnum.
+type EnumValue interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~string
+}
+
+// enumSet holds the allowed values of an enum type keyed by the value
+// of reflect.Value.Int, Uint or String, whichever applies to its kind.
+type enumSet struct {
+	ints    map[int64]struct{}
+	uints   map[uint64]struct{}
+	strings map[string]struct{}
+	// isValid is set when membership is decided by the IsValid method.
+	isValid bool
+}
+
+// enumSets maps a reflect.Type to its *enumSet, or to nil if the type is
+// not an enum.
+var enumSets sync.Map
+
+// RegisterEnum sets the values allowed by the enum rule for fields of
+// type T, replacing any set registered before, e.g.
+//
+//	validator.RegisterEnum(StatusDraft, StatusReview, StatusPublished)
+//
+// The validatorenum command generates these calls from const blocks.
+func RegisterEnum[T EnumValue](values ...T) {
+	set := newEnumSet()
+	for _, value := range values {
+		set.add(reflect.ValueOf(value))
+	}
+	enumSets.Store(reflect.TypeFor[T](), set)
+}
+
+func newEnumSet() *enumSet {
+	return &enumSet{
+		ints:    make(map[int64]struct{}),
+		uints:   make(map[uint64]struct{}),
+		strings: make(map[string]struct{}),
+	}
+}
+
+func (s *enumSet) add(v reflect.Value) {
+	switch {
+	case v.CanInt():
+		s.ints[v.Int()] = struct{}{}
+	case v.CanUint():
+		s.uints[v.Uint()] = struct{}{}
+	case v.Kind() == reflect.String:
+		s.strings[v.String()] = struct{}{}
+	}
+}
+
+func (s *enumSet) contains(v reflect.Value) bool {
+	if s.isValid {
+		if !v.CanInterface() {
+			v = exportedCopy(v)
+		}
+		return v.Interface().(interface{ IsValid() bool }).IsValid()
+	}
+	var ok bool
+	switch {
+	case v.CanInt():
+		_, ok = s.ints[v.Int()]
+	case v.CanUint():
+		_, ok = s.uints[v.Uint()]
+	case v.Kind() == reflect.String:
+		_, ok = s.strings[v.String()]
+	}
+	return ok
+}
+
+// enumSetOf returns the allowed values of t: the ones registered with
+// RegisterEnum, else the ones returned by a `Values() []T` method, else
+// the ones accepted by an `IsValid() bool` method. It returns nil if t
+// is none of these.
+func enumSetOf(t reflect.Type) *enumSet {
+	if set, ok := enumSets.Load(t); ok {
+		return set.(*enumSet)
+	}
+	var set *enumSet
+	if method, ok := t.MethodByName("Values"); ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 && method.Type.Out(0) == reflect.SliceOf(t) {
+		set = newEnumSet()
+		values := method.Func.Call([]reflect.Value{reflect.Zero(t)})[0]
+		for i := 0; i < values.Len(); i++ {
+			set.add(values.Index(i))
+		}
+	} else if t.Implements(reflect.TypeFor[interface{ IsValid() bool }]()) {
+		set = &enumSet{isValid: true}
+	}
+	actual, _ := enumSets.LoadOrStore(t, set)
+	return actual.(*enumSet)
+}
+
+func checkEnum(fieldName string, field reflect.Value) error {
+	if field.Kind() == reflect.Slice {
+		for i := 0; i < field.Len(); i++ {
+			if err := checkEnum(fieldName, field.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		// A nil pointer or interface is left to required; otherwise the
+		// value it holds is checked.
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	set := enumSetOf(field.Type())
+	if set == nil {
+		return NewValidationError(fmt.Errorf("%w: %s is not an enum", ErrInvalidValidatorSyntax, field.Type()), fieldName)
+	}
+	if !set.contains(field) {
+		return NewValidationError(ErrEnumValidationFailed, fieldName)
+	}
+	return nil
+}
+
+// exportedCopy returns a copy of v, which was obtained through unexported
+// struct fields, that allows calling Interface.
+func exportedCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch {
+	case v.CanInt():
+		c.SetInt(v.Int())
+	case v.CanUint():
+		c.SetUint(v.Uint())
+	case v.Kind() == reflect.String:
+		c.SetString(v.String())
+	}
+	return c
+}
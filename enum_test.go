@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+type enumStatus string
+
+const (
+	enumStatusDraft     enumStatus = "draft"
+	enumStatusPublished enumStatus = "published"
+)
+
+type enumLevel int
+
+func (enumLevel) Values() []enumLevel { return []enumLevel{1, 2, 3} }
+
+type enumColor uint8
+
+func (c enumColor) IsValid() bool { return c < 3 }
+
+type enumPost struct {
+	Status enumStatus   `validate:"enum"`
+	Level  enumLevel    `validate:"enum"`
+	Color  enumColor    `validate:"enum"`
+	Tags   []enumStatus `validate:"enum"`
+	Next   *enumStatus  `validate:"enum"`
+	Hue    *enumColor   `validate:"enum"`
+}
+
+func init() {
+	RegisterEnum(enumStatusDraft, enumStatusPublished)
+}
+
+func TestValidateEnum(t *testing.T) {
+	published := enumStatusPublished
+	hue := enumColor(2)
+	valid := enumPost{Status: enumStatusDraft, Level: 2, Color: 1, Tags: []enumStatus{enumStatusPublished}, Next: &published, Hue: &hue}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("valid value: %v", err)
+	}
+
+	archived := enumStatus("archived")
+	badHue := enumColor(7)
+	invalid := enumPost{Status: "deleted", Level: 4, Color: 3, Tags: []enumStatus{enumStatusDraft, "x"}, Next: &archived, Hue: &badHue}
+	err := Validate(invalid)
+	want := "Status: enum validation failed\n" +
+		"Level: enum validation failed\n" +
+		"Color: enum validation failed\n" +
+		"Tags: enum validation failed\n" +
+		"Next: enum validation failed\n" +
+		"Hue: enum validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	if !errors.Is(err, ErrEnumValidationFailed) {
+		t.Fatalf("got %v, want ErrEnumValidationFailed", err)
+	}
+}
+
+func TestValidateEnumNilPointer(t *testing.T) {
+	v := enumPost{Status: enumStatusDraft, Level: 1}
+	if err := Validate(v); err != nil {
+		t.Fatalf("got %v, want nil pointers to pass", err)
+	}
+}
+
+func TestValidateEnumInterface(t *testing.T) {
+	type withInterface struct {
+		Color interface{ IsValid() bool } `validate:"enum"`
+	}
+	if err := Validate(withInterface{}); err != nil {
+		t.Fatalf("got %v, want a nil interface to pass", err)
+	}
+	if err := Validate(withInterface{Color: enumColor(1)}); err != nil {
+		t.Fatalf("got %v", err)
+	}
+	if err := Validate(withInterface{Color: enumColor(5)}); !errors.Is(err, ErrEnumValidationFailed) {
+		t.Fatalf("got %v, want ErrEnumValidationFailed", err)
+	}
+}
+
+func TestValidateEnumNotEnum(t *testing.T) {
+	type notEnum struct {
+		Name string `validate:"enum"`
+	}
+	if err := Validate(notEnum{Name: "a"}); !errors.Is(err, ErrInvalidValidatorSyntax) {
+		t.Fatalf("got %v, want ErrInvalidValidatorSyntax", err)
+	}
+}
+
+func TestRegisterEnumReplaces(t *testing.T) {
+	type enumRole int
+	RegisterEnum[enumRole](1, 2)
+	RegisterEnum[enumRole](3)
+	type user struct {
+		Role enumRole `validate:"enum"`
+	}
+	if err := Validate(user{Role: 3}); err != nil {
+		t.Fatalf("got %v", err)
+	}
+	if err := Validate(user{Role: 1}); !errors.Is(err, ErrEnumValidationFailed) {
+		t.Fatalf("got %v, want ErrEnumValidationFailed", err)
+	}
+}
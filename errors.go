@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Errors is the aggregate error returned by Validate. It holds every
+// failure found in the validated value, deduplicated and in a
+// deterministic order, so that Error() is stable between runs.
+type Errors []*ValidationError
+
+func (e Errors) Error() string {
+	var b strings.Builder
+	for i, err := range e {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e Errors) Unwrap() []error {
+	res := make([]error, len(e))
+	for i, err := range e {
+		res[i] = err
+	}
+	return res
+}
+
+func (e *Errors) add(err error, path, rule string) {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		validationErr = &ValidationError{err: err}
+	}
+	validationErr.path = path
+	validationErr.rule = rule
+	*e = append(*e, validationErr)
+}
+
+// Failure describes a failed rule to an ErrorFactory.
+type Failure struct {
+	Path  string
+	Field string
+	// Rule and Param are the failed rule and its parameter, with variables
+	// resolved. Rule is empty for failures not tied to a rule, such as
+	// syntax errors in a tag.
+	Rule  string
+	Param string
+	// Value is the value that failed, or the zero Value if there is none.
+	Value reflect.Value
+	// Params are the hints set by WithHints, or nil.
+	Params map[string]any
+	// Err is the error Validate would report, which wraps a package
+	// sentinel such as ErrLenValidationFailed.
+	Err error
+}
+
+// ErrorFactory builds the error reported for a failure, e.g. an
+// application error type carrying an HTTP status.
+type ErrorFactory func(Failure) error
+
+// WithErrorFactory makes Validate, and the functions that validate values
+// like it, report each failure found while walking the value as the error
+// built by f. The error is returned by ValidationError.Unwrap, so errors.As
+// finds it, while the ValidationError keeps its path and rule and still
+// matches the package sentinels with errors.Is.
+func WithErrorFactory(f ErrorFactory) Option {
+	return func(o *options) {
+		o.newError = f
+	}
+}
+
+// fail records err, a failure of rule on value at path, with the hints and
+// message selected by o and built by its ErrorFactory if it has one.
+func (e *Errors) fail(err error, path string, rule Rule, value reflect.Value, o *options) {
+	e.add(err, path, rule.Name)
+	validationErr := (*e)[len(*e)-1]
+	if o.hints || o.messages != nil {
+		validationErr.explain(rule, value, o)
+	}
+	if o.newError == nil {
+		return
+	}
+	validationErr.sentinel = validationErr.err
+	validationErr.err = o.newError(Failure{
+		Path:   path,
+		Field:  validationErr.field,
+		Rule:   rule.Name,
+		Param:  rule.Param,
+		Value:  value,
+		Params: validationErr.params,
+		Err:    validationErr.sentinel,
+	})
+}
+
+// normalize drops failures with the same path, rule and message and
+// sorts the rest according to order.
+func (e Errors) normalize(order Order) Errors {
+	if len(e) == 0 {
+		return e
+	}
+	seen := make(map[string]struct{}, len(e))
+	res := e[:0]
+	for _, err := range e {
+		key := err.path + "\x00" + err.rule + "\x00" + err.err.Error()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, err)
+	}
+	if order == OrderPath {
+		slices.SortStableFunc(res, func(a, b *ValidationError) int {
+			return cmp.Or(strings.Compare(a.path, b.path), strings.Compare(a.rule, b.rule))
+		})
+	}
+	return res
+}
+
+// NewErrors returns an empty Errors for collecting failures found outside
+// Validate, such as checks against a database, in the form Validate
+// reports them:
+//
+//	errs := validator.NewErrors()
+//	errs.Merge(validator.Validate(req))
+//	if !exists {
+//		errs.Add("Address.Zip", "exists", map[string]any{"zip": req.Address.Zip})
+//	}
+//	return errs.Err()
+func NewErrors() *Errors {
+	return new(Errors)
+}
+
+// ruleErrors maps the rules built into Validate to their sentinels.
+var ruleErrors = map[string]error{
+	"len":          ErrLenValidationFailed,
+	"in":           ErrInValidationFailed,
+	"max":          ErrMaxValidationFailed,
+	"min":          ErrMinValidationFailed,
+	"required":     ErrRequiredValidationFailed,
+	"email":        ErrEmailValidationFailed,
+	"enum":         ErrEnumValidationFailed,
+	"immutable":    ErrImmutableValidationFailed,
+	"monotonic":    ErrMonotonicValidationFailed,
+	"transition":   ErrTransitionValidationFailed,
+	"nobidi":       ErrNoBidiValidationFailed,
+	"noinvisible":  ErrNoInvisibleValidationFailed,
+	"nocontrol":    ErrNoControlValidationFailed,
+	"singlescript": ErrSingleScriptValidationFailed,
+	"filename":     ErrFilenameValidationFailed,
+	"safepath":     ErrSafePathValidationFailed,
+	"ext":          ErrExtValidationFailed,
+}
+
+// Add records a failure of rule code at path, e.g. "Items[2].Qty", with
+// the message "<code> validation failed" and params, see
+// ValidationError.Params. The failure of a rule built into Validate
+// matches its sentinel, e.g. ErrMinValidationFailed for "min".
+func (e *Errors) Add(path, code string, params map[string]any) {
+	field := ""
+	for _, segment := range splitPath(path) {
+		if !strings.HasPrefix(segment, "[") {
+			field = segment
+		}
+	}
+	err, ok := ruleErrors[code]
+	if !ok {
+		err = errors.New(code + " validation failed")
+	}
+	*e = append(*e, &ValidationError{
+		field:  field,
+		path:   path,
+		rule:   code,
+		params: params,
+		err:    err,
+	})
+}
+
+// Merge records the failures in err, as returned by Validate, including
+// all those held by errors joined with errors.Join. Any other non-nil
+// error is recorded as a failure of the whole value.
+func (e *Errors) Merge(err error) {
+	var validationErr *ValidationError
+	switch err := err.(type) {
+	case nil:
+	case Errors:
+		*e = append(*e, err...)
+	case *ValidationError:
+		*e = append(*e, err)
+	case interface{ Unwrap() []error }:
+		for _, err := range err.Unwrap() {
+			e.Merge(err)
+		}
+	default:
+		if wrapper, ok := err.(interface{ Unwrap() error }); ok && errors.As(err, &validationErr) {
+			e.Merge(wrapper.Unwrap())
+			return
+		}
+		e.add(err, "", "")
+	}
+}
+
+// Err returns the recorded failures deduplicated and ordered as Validate
+// does with opts, or nil if there are none. Only WithOrder affects it.
+func (e *Errors) Err(opts ...Option) error {
+	o := newOptions(opts)
+	return slices.Clone(*e).result(o.order)
+}
+
+// result returns e normalized according to order, or nil if it is empty.
+func (e Errors) result(order Order) error {
+	e = e.normalize(order)
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+type orderAddress struct {
+	Zip  string `validate:"len:5"`
+	City string `validate:"min:2"`
+}
+
+type orderUser struct {
+	Name    string `validate:"len:3|min:2"`
+	Address orderAddress
+	Age     int `validate:"min:18"`
+}
+
+func TestValidateOrder(t *testing.T) {
+	v := orderUser{Name: "a", Address: orderAddress{Zip: "1", City: "x"}, Age: 1}
+	tests := []struct {
+		name  string
+		order Order
+		want  string
+	}{
+		{
+			name:  "declaration",
+			order: OrderDeclaration,
+			want: "Name: len validation failed\n" +
+				"Name: min validation failed\n" +
+				"Address.Zip: len validation failed\n" +
+				"Address.City: min validation failed\n" +
+				"Age: min validation failed",
+		},
+		{
+			name:  "path",
+			order: OrderPath,
+			want: "Address.City: min validation failed\n" +
+				"Address.Zip: len validation failed\n" +
+				"Age: min validation failed\n" +
+				"Name: len validation failed\n" +
+				"Name: min validation failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(v, WithOrder(tt.order))
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStableError(t *testing.T) {
+	v := orderUser{Name: "a", Address: orderAddress{Zip: "1"}}
+	first := Validate(v, WithOrder(OrderPath)).Error()
+	for i := 0; i < 20; i++ {
+		if got := Validate(v, WithOrder(OrderPath)).Error(); got != first {
+			t.Fatalf("run %d: got:\n%s\nwant:\n%s", i, got, first)
+		}
+	}
+}
+
+func TestErrorsNormalize(t *testing.T) {
+	var errs Errors
+	errs.add(NewValidationError(ErrMinValidationFailed, "B"), "B", "min")
+	errs.add(NewValidationError(ErrLenValidationFailed, "A"), "A", "len")
+	errs.add(NewValidationError(ErrMinValidationFailed, "B"), "B", "min")
+	errs.add(NewValidationError(ErrMaxValidationFailed, "B"), "B", "max")
+	errs.add(NewValidationError(ErrLenValidationFailed, "A"), "A", "len")
+
+	tests := []struct {
+		name  string
+		order Order
+		want  []string
+	}{
+		{"declaration", OrderDeclaration, []string{"B min", "A len", "B max"}},
+		{"path", OrderPath, []string{"A len", "B max", "B min"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range append(Errors(nil), errs...).normalize(tt.order) {
+				got = append(got, err.Path()+" "+err.Rule())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorsKeepDifferentMessages(t *testing.T) {
+	var errs Errors
+	errs.add(NewValidationError(ErrInvalidValidatorSyntax, "A"), "A", "")
+	errs.add(NewValidationError(ErrValidateForUnexportedFields, "A"), "A", "")
+	if got := errs.normalize(OrderPath); len(got) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(got), got)
+	}
+}
+
+func TestErrorsResultNil(t *testing.T) {
+	if err := Errors(nil).result(OrderPath); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if err := Validate(orderUser{Name: "abc", Address: orderAddress{Zip: "12345", City: "ab"}, Age: 18}); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+}
+
+func TestErrorsUnwrap(t *testing.T) {
+	err := Validate(orderUser{Name: "abc", Address: orderAddress{Zip: "1", City: "ab"}, Age: 18})
+	if !errors.Is(err, ErrLenValidationFailed) {
+		t.Fatalf("errors.Is(%v, ErrLenValidationFailed) = false", err)
+	}
+	if errors.Is(err, ErrMinValidationFailed) {
+		t.Fatalf("errors.Is(%v, ErrMinValidationFailed) = true", err)
+	}
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("errors.As(%v, *Errors) gave %v", err, errs)
+	}
+	e := errs[0]
+	if e.Field() != "Zip" || e.Path() != "Address.Zip" || e.Rule() != "len" {
+		t.Fatalf("got field %q, path %q, rule %q", e.Field(), e.Path(), e.Rule())
+	}
+	if e.Error() != "Address.Zip: len validation failed" {
+		t.Fatalf("got %q", e.Error())
+	}
+}
+
+func TestValidateNotStruct(t *testing.T) {
+	if err := Validate(1); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	errs := NewErrors()
+	if err := errs.Err(); err != nil {
+		t.Fatalf("empty builder: got %v", err)
+	}
+	errs.Merge(nil)
+	errs.Merge(Validate(orderUser{Name: "abc", Address: orderAddress{Zip: "12345", City: "ab"}, Age: 1}))
+	errs.Add("Items[2].Qty", "min", map[string]any{"min": 1})
+	errs.Add("Address.Zip", "exists", nil)
+	errs.Add("Address.Zip", "exists", nil)
+	err := errs.Err(WithOrder(OrderPath))
+	want := "Address.Zip: exists validation failed\n" +
+		"Age: min validation failed\n" +
+		"Items[2].Qty: min validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	var all Errors
+	if !errors.As(err, &all) {
+		t.Fatal("not Errors")
+	}
+	added := all[2]
+	if added.Field() != "Qty" || added.Rule() != "min" || added.Params()["min"] != 1 {
+		t.Fatalf("got field %q, rule %q, params %v", added.Field(), added.Rule(), added.Params())
+	}
+	if !errors.Is(added, ErrMinValidationFailed) {
+		t.Fatalf("Add(min) does not match ErrMinValidationFailed")
+	}
+	if errors.Is(all[0], ErrMinValidationFailed) {
+		t.Fatalf("Add(exists) matches ErrMinValidationFailed")
+	}
+}
+
+func TestErrorsMerge(t *testing.T) {
+	first := Validate(orderUser{Name: "a", Address: orderAddress{Zip: "12345", City: "ab"}, Age: 18})
+	second := Validate(orderUser{Name: "abc", Address: orderAddress{Zip: "1", City: "ab"}, Age: 18})
+	other := errors.New("database unavailable")
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, []string{}},
+		{"errors", first, []string{"Name len", "Name min"}},
+		{"single", first.(Errors)[0], []string{"Name len"}},
+		{"wrapped", fmt.Errorf("checking user: %w", first), []string{"Name len", "Name min"}},
+		{"joined", errors.Join(first, second), []string{"Name len", "Name min", "Address.Zip len"}},
+		{"wrapped join", fmt.Errorf("batch: %w", errors.Join(second, first)), []string{"Address.Zip len", "Name len", "Name min"}},
+		{"multiple %w", fmt.Errorf("%w; %w", first.(Errors)[1], other), []string{"Name min", " "}},
+		{"other", other, []string{" "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := NewErrors()
+			errs.Merge(tt.err)
+			got := make([]string, len(*errs))
+			for i, err := range *errs {
+				got[i] = err.Path() + " " + err.Rule()
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
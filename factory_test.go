@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type apiError struct {
+	status  int
+	failure Failure
+}
+
+func (e *apiError) Error() string {
+	return e.failure.Rule + " failed with " + e.failure.Param
+}
+
+func (e *apiError) Unwrap() error {
+	return e.failure.Err
+}
+
+type factoryAccount struct {
+	Name  string `validate:"min:3"`
+	Plan  string `validate:"in:free,pro"`
+	Limit int    `validate:"max:$plan.limit"`
+}
+
+var factoryParams = Params{"plan.limit": "5"}
+
+func newAPIError(f Failure) error {
+	return &apiError{status: http.StatusUnprocessableEntity, failure: f}
+}
+
+func TestWithErrorFactory(t *testing.T) {
+	v := factoryAccount{Name: "ab", Plan: "free", Limit: 7}
+	err := Validate(v, WithErrorFactory(newAPIError), WithParams(factoryParams))
+	want := "Name: min failed with 3\nLimit: max failed with 5"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As did not find *apiError in %v", err)
+	}
+	if apiErr.status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d", apiErr.status)
+	}
+	f := apiErr.failure
+	if f.Path != "Name" || f.Field != "Name" || f.Rule != "min" || f.Param != "3" || f.Value.String() != "ab" {
+		t.Errorf("failure = %+v", f)
+	}
+	if !errors.Is(f.Err, ErrMinValidationFailed) {
+		t.Errorf("Failure.Err = %v, want ErrMinValidationFailed", f.Err)
+	}
+
+	for _, sentinel := range []error{ErrMinValidationFailed, ErrMaxValidationFailed} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(err, %v) = false", sentinel)
+		}
+	}
+	if errors.Is(err, ErrLenValidationFailed) {
+		t.Error("errors.Is(err, ErrLenValidationFailed) = true")
+	}
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("errors = %v", err)
+	}
+	if errs[1].Path() != "Limit" || errs[1].Rule() != "max" {
+		t.Errorf("second error at %q with rule %q", errs[1].Path(), errs[1].Rule())
+	}
+	if !errors.As(errs[1], &apiErr) || apiErr.failure.Value.Int() != 7 {
+		t.Errorf("second failure = %+v", apiErr.failure)
+	}
+}
+
+func TestWithErrorFactoryPlainError(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	v := factoryAccount{Name: "abc", Plan: "team"}
+	err := Validate(v, WithParams(factoryParams), WithErrorFactory(func(Failure) error { return errForbidden }))
+	if err == nil || err.Error() != "Plan: forbidden" {
+		t.Fatalf("got %v", err)
+	}
+	// The sentinel matches even though the factory error does not wrap it.
+	if !errors.Is(err, errForbidden) || !errors.Is(err, ErrInValidationFailed) {
+		t.Errorf("errors.Is does not match both errors of %v", err)
+	}
+}
+
+func TestWithErrorFactoryHints(t *testing.T) {
+	var got Failure
+	v := factoryAccount{Name: "abc", Plan: "fre"}
+	err := Validate(v, WithParams(factoryParams), WithHints(), WithErrorFactory(func(f Failure) error {
+		got = f
+		return f.Err
+	}))
+	want := `Plan: in validation failed: did you mean "free"?`
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	if suggestions, _ := got.Params["suggestions"].([]string); len(suggestions) == 0 || suggestions[0] != "free" {
+		t.Errorf("Failure.Params = %v", got.Params)
+	}
+}
+
+func TestWithErrorFactoryValid(t *testing.T) {
+	called := false
+	v := factoryAccount{Name: "abc", Plan: "pro", Limit: 5}
+	err := Validate(v, WithParams(factoryParams), WithErrorFactory(func(f Failure) error {
+		called = true
+		return f.Err
+	}))
+	if err != nil || called {
+		t.Fatalf("got %v, factory called: %v", err, called)
+	}
+}
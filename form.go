@@ -0,0 +1,202 @@
+package validator
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxFormIndex bounds the indexes accepted in keys such as "items[0].name",
+// so that a request cannot make BindValues allocate huge slices.
+const maxFormIndex = 1000
+
+// BindValues sets the fields of the struct v points to from values, such as
+// a parsed form or query string, and validates it.
+//
+// Keys are matched against the `form` tag of each field, or its name if it
+// has none; a tag of "-" leaves the field out. Nested structs are reached
+// with dotted keys such as "address.zip" and slice elements with indexed
+// keys such as "items[0].name", while a repeated key fills a slice with all
+// its values. Keys that match no field, or are empty, are ignored. Values that cannot be
+// converted to the kind of their field are reported as ValidationErrors
+// wrapping ErrTypeMismatch with the rule "type_mismatch", next to the
+// failures found by Validate, using the same Go paths.
+func BindValues(values url.Values, v any, opts ...Option) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return NewValidationError(ErrNotStruct, "")
+	}
+	o := newOptions(opts)
+	var resErrors Errors
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		// An empty key names no field, not the struct itself.
+		if segments := splitPath(key); len(segments) > 0 {
+			bindValue(value.Elem(), segments, "", "", values[key], &resErrors)
+		}
+	}
+	for _, err := range validateErrors(value.Elem(), &o) {
+		if !resErrors.covers(err.path) {
+			resErrors = append(resErrors, err)
+		}
+	}
+	return resErrors.result(o.order)
+}
+
+// bindValue sets the part of v named by the key segments to values. path
+// and fieldName locate v in the bound struct.
+func bindValue(v reflect.Value, segments []string, path, fieldName string, values []string, resErrors *Errors) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if len(segments) == 0 {
+		if err := setFormValue(v, values); err != nil {
+			resErrors.add(NewValidationError(err, fieldName), path, "type_mismatch")
+		}
+		return
+	}
+	segment := segments[0]
+	if strings.HasPrefix(segment, "[") {
+		if v.Kind() != reflect.Slice {
+			return
+		}
+		i, err := strconv.Atoi(strings.Trim(segment, "[]"))
+		if err != nil || i < 0 || i >= maxFormIndex {
+			err = fmt.Errorf("%w: invalid index %s", ErrTypeMismatch, segment)
+			resErrors.add(NewValidationError(err, fieldName), path+segment, "type_mismatch")
+			return
+		}
+		if i >= v.Len() {
+			v.Set(reflect.AppendSlice(v, reflect.MakeSlice(v.Type(), i+1-v.Len(), i+1-v.Len())))
+		}
+		bindValue(v.Index(i), segments[1:], path+segment, fieldName, values, resErrors)
+		return
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	field, ok := lookupFormField(v.Type(), segment)
+	if !ok {
+		return
+	}
+	bindValue(v.FieldByIndex(field.Index), segments[1:], joinPath(path, field.path), field.Name, values, resErrors)
+}
+
+// formField is a struct field reachable from a form key.
+type formField struct {
+	reflect.StructField
+	key string
+	// path is the dotted Go path to the field, which goes through the
+	// embedded structs it is promoted from.
+	path string
+}
+
+// lookupFormField finds the field of struct type t for key: an exact match
+// of its form key first, then a case-insensitive one.
+func lookupFormField(t reflect.Type, key string) (formField, bool) {
+	fields := formFields(t)
+	for _, field := range fields {
+		if field.key == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.key, key) {
+			return field, true
+		}
+	}
+	return formField{}, false
+}
+
+func formFields(t reflect.Type) []formField {
+	res := make([]formField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		key, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && key == "" && field.Type.Kind() == reflect.Struct {
+			for _, promoted := range formFields(field.Type) {
+				promoted.Index = append([]int{i}, promoted.Index...)
+				promoted.path = joinPath(field.Name, promoted.path)
+				res = append(res, promoted)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		res = append(res, formField{StructField: field, key: key, path: field.Name})
+	}
+	return res
+}
+
+// setFormValue converts values into v: all of them for a slice, the first
+// one otherwise.
+func setFormValue(v reflect.Value, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if v.Kind() == reflect.Slice && !v.Addr().Type().Implements(textUnmarshalerType) {
+		res := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setFormValue(res.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		v.Set(res)
+		return nil
+	}
+	value := values[0]
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("%w: %s", ErrTypeMismatch, err)
+		}
+		return nil
+	}
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(value); value == "on" {
+			b, err = true, nil
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, v.Type().Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, v.Type().Bits())
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(value, v.Type().Bits())
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: cannot bind into %s", ErrTypeMismatch, v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: expected %s, got %q", ErrTypeMismatch, v.Kind(), value)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type formAddress struct {
+	Zip string `form:"zip" validate:"len:5"`
+}
+
+type formMeta struct {
+	Source string `form:"source"`
+}
+
+type formItem struct {
+	Name string `form:"name" validate:"required"`
+	Qty  int    `form:"qty" validate:"min:1"`
+}
+
+type formSignup struct {
+	formMeta
+	Name    string       `form:"name" validate:"min:3"`
+	Age     int          `form:"age" validate:"min:18"`
+	Score   float64      `form:"score"`
+	Admin   bool         `form:"admin"`
+	Tags    []string     `form:"tag" validate:"dive|len:2"`
+	Address formAddress  `form:"address"`
+	Items   []formItem   `form:"items"`
+	Ptr     *formAddress `form:"ptr"`
+	Birth   time.Time    `form:"birth"`
+	Secret  string       `form:"-"`
+	Limit   uint8
+}
+
+func TestBindValues(t *testing.T) {
+	values := url.Values{
+		"name":          {"alice", "ignored"},
+		"age":           {"30"},
+		"score":         {"9.5"},
+		"admin":         {"on"},
+		"tag":           {"go", "js"},
+		"address.zip":   {"12345"},
+		"items[1].name": {"pen"},
+		"items[1].qty":  {"2"},
+		"items[0].name": {"ink"},
+		"items[0].qty":  {"1"},
+		"ptr.zip":       {"54321"},
+		"birth":         {"2000-01-02T00:00:00Z"},
+		"source":        {"ad"},
+		"Secret":        {"s"},
+		"LIMIT":         {"7"},
+		"unknown.field": {"x"},
+	}
+	var got formSignup
+	if err := BindValues(values, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := formSignup{
+		formMeta: formMeta{Source: "ad"},
+		Name:     "alice",
+		Age:      30,
+		Score:    9.5,
+		Admin:    true,
+		Tags:     []string{"go", "js"},
+		Address:  formAddress{Zip: "12345"},
+		Items:    []formItem{{Name: "ink", Qty: 1}, {Name: "pen", Qty: 2}},
+		Ptr:      &formAddress{Zip: "54321"},
+		Birth:    time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+		Limit:    7,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestBindValuesErrors(t *testing.T) {
+	values := url.Values{
+		"name":          {"al"},
+		"age":           {"old"},
+		"admin":         {"maybe"},
+		"tag":           {"go", "rust"},
+		"address.zip":   {"1"},
+		"items[0].qty":  {"0"},
+		"items[5000].q": {"1"},
+		"items[x].name": {"a"},
+		"birth":         {"yesterday"},
+		"Limit":         {"300"},
+	}
+	var v formSignup
+	err := BindValues(values, &v, WithOrder(OrderPath))
+	want := "Address.Zip: len validation failed\n" +
+		"Admin: type mismatch: expected bool, got \"maybe\"\n" +
+		"Age: type mismatch: expected int, got \"old\"\n" +
+		"Birth: type mismatch: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"\n" +
+		"Items[0].Name: required validation failed\n" +
+		"Items[0].Qty: min validation failed\n" +
+		"Items[5000]: type mismatch: invalid index [5000]\n" +
+		"Items[x]: type mismatch: invalid index [x]\n" +
+		"Limit: type mismatch: expected uint8, got \"300\"\n" +
+		"Name: min validation failed\n" +
+		"Tags[1]: len validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	var errs Errors
+	if !errors.As(err, &errs) || !errors.Is(errs[1], ErrTypeMismatch) || errs[1].Rule() != "type_mismatch" {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestBindValuesEmptyKey(t *testing.T) {
+	var v formSignup
+	err := BindValues(url.Values{"": {"x"}, "name": {"al"}}, &v, WithOrder(OrderPath))
+	want := "Address.Zip: len validation failed\nAge: min validation failed\nName: min validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestBindValuesNotStruct(t *testing.T) {
+	if err := BindValues(url.Values{}, formSignup{}); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+}
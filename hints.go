@@ -0,0 +1,252 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// emailExample is the example of a valid value given in email hints.
+const emailExample = "user@example.com"
+
+// WithHints makes failures carry hints on how to fix the value, in
+// ValidationError.Params and appended to the message:
+//
+//	in, enum       "allowed" and "suggestions", the allowed values closest
+//	               to the value by edit distance:
+//	               `in validation failed: did you mean "admin"?`
+//	len, min, max  "length" of a string, or "value" of a number, and the
+//	               allowed "min" and "max":
+//	               "max validation failed: length is 25, want at most 20"
+//	email          "example" of a valid address
+//
+// Hints are only computed for failing values, so they cost nothing when
+// the value is valid.
+func WithHints() Option {
+	return func(o *options) {
+		o.hints = true
+	}
+}
+
+// WithMessages sets templates for the messages of failures by rule name,
+// e.g. {"in": "{field} must be one of {allowed}"}. A template refers to
+// {path}, {field}, {rule}, {param} and the parameters of the failure, see
+// WithHints; lists are joined with ", " and unknown names are kept as
+// written. The failure still matches its sentinel with errors.Is.
+func WithMessages(templates map[string]string) Option {
+	return func(o *options) {
+		o.messages = templates
+	}
+}
+
+// hintError replaces the message of err.
+type hintError struct {
+	msg string
+	err error
+}
+
+func (e *hintError) Error() string {
+	return e.msg
+}
+
+func (e *hintError) Unwrap() error {
+	return e.err
+}
+
+// explain sets the hints and message selected by o for e, a failure of
+// rule on value.
+func (e *ValidationError) explain(rule Rule, value reflect.Value, o *options) {
+	var hint string
+	if o.hints {
+		hint = e.addHints(rule, value)
+	}
+	if template, ok := o.messages[e.rule]; ok {
+		e.err = &hintError{msg: e.expand(template, rule), err: e.err}
+	} else if hint != "" {
+		e.err = &hintError{msg: e.err.Error() + ": " + hint, err: e.err}
+	}
+}
+
+// addHints sets the hint parameters for a failure of rule on value and
+// returns the hint for the message.
+func (e *ValidationError) addHints(rule Rule, value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+	params := make(map[string]any)
+	var hint string
+	switch {
+	case errors.Is(e.err, ErrInValidationFailed):
+		hint = suggest(params, formatScalar(value), strings.Split(rule.Param, ","))
+	case errors.Is(e.err, ErrEnumValidationFailed):
+		if set := enumSetOf(value.Type()); set != nil && !set.isValid {
+			hint = suggest(params, formatScalar(value), set.values())
+		}
+	case errors.Is(e.err, ErrLenValidationFailed), errors.Is(e.err, ErrMinValidationFailed), errors.Is(e.err, ErrMaxValidationFailed):
+		hint = rangeHint(params, rule, value)
+	case errors.Is(e.err, ErrEmailValidationFailed):
+		params["example"] = emailExample
+		hint = "want an address like " + emailExample
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	if e.params == nil {
+		e.params = params
+	} else {
+		for key, v := range params {
+			e.params[key] = v
+		}
+	}
+	return hint
+}
+
+// suggest sets the allowed values and the ones closest to value, and
+// returns a did-you-mean hint if any is close enough.
+func suggest(params map[string]any, value string, allowed []string) string {
+	params["allowed"] = allowed
+	type candidate struct {
+		value    string
+		distance int
+	}
+	limit := max(2, len(value)/3)
+	candidates := make([]candidate, 0)
+	for _, a := range allowed {
+		if d := editDistance(value, a); d <= limit {
+			candidates = append(candidates, candidate{a, d})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.distance - b.distance
+	})
+	suggestions := make([]string, 0, 3)
+	for _, c := range candidates[:min(len(candidates), 3)] {
+		suggestions = append(suggestions, c.value)
+	}
+	params["suggestions"] = suggestions
+	if len(suggestions) == 0 {
+		return "want one of " + strings.Join(allowed, ", ")
+	}
+	return fmt.Sprintf("did you mean %q?", suggestions[0])
+}
+
+// rangeHint sets the current length or value and the allowed range of a
+// len, min or max failure and returns a hint describing them.
+func rangeHint(params map[string]any, rule Rule, value reflect.Value) string {
+	n, err := strconv.Atoi(rule.Param)
+	if err != nil {
+		return ""
+	}
+	var what string
+	var current int64
+	switch {
+	case value.Kind() == reflect.String:
+		what, current = "length", int64(len(value.String()))
+	case value.CanInt():
+		what, current = "value", value.Int()
+	default:
+		return ""
+	}
+	params[what] = current
+	switch rule.Name {
+	case "len":
+		params["min"], params["max"] = n, n
+		return fmt.Sprintf("%s is %d, want %d", what, current, n)
+	case "min":
+		params["min"] = n
+		return fmt.Sprintf("%s is %d, want at least %d", what, current, n)
+	case "max":
+		params["max"] = n
+		return fmt.Sprintf("%s is %d, want at most %d", what, current, n)
+	}
+	return ""
+}
+
+// expand fills in the placeholders of template for e.
+func (e *ValidationError) expand(template string, rule Rule) string {
+	var b strings.Builder
+	for {
+		before, rest, ok := strings.Cut(template, "{")
+		b.WriteString(before)
+		if !ok {
+			return b.String()
+		}
+		name, after, ok := strings.Cut(rest, "}")
+		if !ok {
+			b.WriteString("{" + rest)
+			return b.String()
+		}
+		template = after
+		switch value, known := e.params[name]; {
+		case name == "path":
+			b.WriteString(e.path)
+		case name == "field":
+			b.WriteString(e.field)
+		case name == "rule":
+			b.WriteString(rule.Name)
+		case name == "param":
+			b.WriteString(rule.Param)
+		case known:
+			if list, ok := value.([]string); ok {
+				b.WriteString(strings.Join(list, ", "))
+			} else {
+				fmt.Fprint(&b, value)
+			}
+		default:
+			b.WriteString("{" + name + "}")
+		}
+	}
+}
+
+func formatScalar(v reflect.Value) string {
+	switch {
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10)
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10)
+	case v.Kind() == reflect.String:
+		return v.String()
+	}
+	return ""
+}
+
+// values returns the allowed values of s formatted as strings, sorted.
+func (s *enumSet) values() []string {
+	res := make([]string, 0, len(s.ints)+len(s.uints)+len(s.strings))
+	for v := range s.ints {
+		res = append(res, strconv.FormatInt(v, 10))
+	}
+	for v := range s.uints {
+		res = append(res, strconv.FormatUint(v, 10))
+	}
+	for v := range s.strings {
+		res = append(res, v)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// editDistance returns the Levenshtein distance between a and b in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type hintRole string
+
+func (hintRole) Values() []hintRole { return []hintRole{"admin", "editor", "viewer"} }
+
+type hintSignup struct {
+	Role    string   `validate:"in:admin,editor,viewer"`
+	Kind    hintRole `validate:"enum"`
+	Name    string   `validate:"max:5"`
+	Code    string   `validate:"len:4"`
+	Age     int      `validate:"min:18"`
+	Email   string   `validate:"email"`
+	Country string   `validate:"in:de,fr"`
+}
+
+func validSignup() hintSignup {
+	return hintSignup{Role: "admin", Kind: "viewer", Name: "ann", Code: "abcd", Age: 30, Email: "ann@example.com", Country: "de"}
+}
+
+func TestValidateWithHints(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*hintSignup)
+		want   string
+		params map[string]any
+	}{
+		{
+			name:   "in",
+			modify: func(s *hintSignup) { s.Role = "admn" },
+			want:   `Role: in validation failed: did you mean "admin"?`,
+			params: map[string]any{"allowed": []string{"admin", "editor", "viewer"}, "suggestions": []string{"admin"}},
+		},
+		{
+			name:   "in without suggestion",
+			modify: func(s *hintSignup) { s.Country = "usa" },
+			want:   "Country: in validation failed: want one of de, fr",
+			params: map[string]any{"allowed": []string{"de", "fr"}, "suggestions": []string{}},
+		},
+		{
+			name:   "enum",
+			modify: func(s *hintSignup) { s.Kind = "editr" },
+			want:   `Kind: enum validation failed: did you mean "editor"?`,
+			params: map[string]any{"allowed": []string{"admin", "editor", "viewer"}, "suggestions": []string{"editor"}},
+		},
+		{
+			name:   "max",
+			modify: func(s *hintSignup) { s.Name = "annabel" },
+			want:   "Name: max validation failed: length is 7, want at most 5",
+			params: map[string]any{"length": int64(7), "max": 5},
+		},
+		{
+			name:   "len",
+			modify: func(s *hintSignup) { s.Code = "ab" },
+			want:   "Code: len validation failed: length is 2, want 4",
+			params: map[string]any{"length": int64(2), "min": 4, "max": 4},
+		},
+		{
+			name:   "min",
+			modify: func(s *hintSignup) { s.Age = 16 },
+			want:   "Age: min validation failed: value is 16, want at least 18",
+			params: map[string]any{"value": int64(16), "min": 18},
+		},
+		{
+			name:   "email",
+			modify: func(s *hintSignup) { s.Email = "ann" },
+			want:   "Email: email validation failed: want an address like user@example.com",
+			params: map[string]any{"example": "user@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := validSignup()
+			tt.modify(&v)
+			err := Validate(v, WithHints())
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+			var errs Errors
+			if !errors.As(err, &errs) || len(errs) != 1 {
+				t.Fatalf("errors = %v", err)
+			}
+			if !reflect.DeepEqual(errs[0].Params(), tt.params) {
+				t.Errorf("params = %#v, want %#v", errs[0].Params(), tt.params)
+			}
+		})
+	}
+}
+
+func TestValidateWithoutHints(t *testing.T) {
+	v := validSignup()
+	v.Role = "admn"
+	err := Validate(v)
+	if err == nil || err.Error() != "Role: in validation failed" {
+		t.Fatalf("got %v", err)
+	}
+	var errs Errors
+	if errors.As(err, &errs) && errs[0].Params() != nil {
+		t.Errorf("params = %v", errs[0].Params())
+	}
+}
+
+func TestValidateWithMessages(t *testing.T) {
+	v := validSignup()
+	v.Role = "admn"
+	v.Age = 16
+	v.Name = "annabel"
+	err := Validate(v, WithHints(), WithMessages(map[string]string{
+		"in":  "{field} must be one of {allowed}, not {unknown}",
+		"min": "{path} is {value}, the {rule} is {param}",
+		"max": "{field} is too long {",
+	}))
+	want := "Role: Role must be one of admin, editor, viewer, not {unknown}\n" +
+		"Name: Name is too long {\n" +
+		"Age: Age is 16, the min is 18"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	for _, sentinel := range []error{ErrInValidationFailed, ErrMinValidationFailed, ErrMaxValidationFailed} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(err, %v) = false", sentinel)
+		}
+	}
+}
+
+func TestValidateWithMessagesWithoutHints(t *testing.T) {
+	v := validSignup()
+	v.Age = 16
+	err := Validate(v, WithMessages(map[string]string{"min": "{field}: {value} < {param}"}))
+	want := "Age: Age: {value} < 18"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		value   string
+		allowed []string
+		want    []string
+	}{
+		{"admn", []string{"admin", "editor", "viewer"}, []string{"admin"}},
+		{"aa", []string{"ab", "ac", "ba", "zz"}, []string{"ab", "ac", "ba"}},
+		{"abcdefgh", []string{"abcdefxy", "abcdefgx"}, []string{"abcdefgx", "abcdefxy"}},
+		{"xyz", []string{"admin"}, []string{}},
+	}
+	for _, tt := range tests {
+		params := make(map[string]any)
+		suggest(params, tt.value, tt.allowed)
+		if got := params["suggestions"]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("suggest(%q, %q) = %q, want %q", tt.value, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"admin", "admn", 1},
+		{"héllo", "hello", 1},
+		{"ab", "ba", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package validator
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidJSON  = errors.New("invalid JSON")
+	ErrUnknownField = errors.New("unknown field")
+	ErrTypeMismatch = errors.New("type mismatch")
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// DecodeAndValidate decodes the JSON document read from r into the struct
+// v points to and validates it. Unlike encoding/json it reports every
+// unknown field and every value of the wrong type, as ValidationErrors
+// wrapping ErrUnknownField and ErrTypeMismatch with the rules
+// "unknown_field" and "type_mismatch", next to the failures found by
+// Validate. A malformed document is reported as a single ErrInvalidJSON
+// with the rule "invalid_json" and is not validated further. Failures of
+// fields that could not be decoded are left out, as the field then only
+// holds its previous value.
+//
+// Paths use Go field names like Validate does, except for unknown fields,
+// which are named by their JSON key. An error reading r is returned as is.
+func DecodeAndValidate(r io.Reader, v any, opts ...Option) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return NewValidationError(ErrNotStruct, "")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	o := newOptions(opts)
+	resErrors := decodeJSON(data, value)
+	if len(resErrors) == 0 || resErrors[0].rule != "invalid_json" {
+		for _, err := range validateErrors(value.Elem(), &o) {
+			if !resErrors.covers(err.path) {
+				resErrors = append(resErrors, err)
+			}
+		}
+	}
+	if o.locateJSON && len(resErrors) > 0 {
+		resErrors.locateJSON(data, value.Type().Elem())
+	}
+	return resErrors.result(o.order)
+}
+
+// covers reports whether e holds an error for path or one of its parents.
+func (e Errors) covers(path string) bool {
+	for _, err := range e {
+		if err.path == "" || path == err.path || strings.HasPrefix(path, err.path+".") || strings.HasPrefix(path, err.path+"[") ||
+			strings.HasPrefix(path, err.path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeJSON unmarshals data into the value v points to and returns the
+// problems found in the document.
+func decodeJSON(data []byte, v reflect.Value) Errors {
+	var resErrors Errors
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var document any
+	if err := dec.Decode(&document); err != nil {
+		offset, err := jsonSyntaxError(err, data, dec.InputOffset())
+		resErrors.add(NewValidationError(err, ""), "", "invalid_json")
+		resErrors[0].pos.Offset = offset
+		return resErrors
+	}
+	offset := skipSeparators(data, dec.InputOffset())
+	if _, err := dec.Token(); err != io.EOF {
+		err = fmt.Errorf("%w: unexpected data after top-level value at offset %d", ErrInvalidJSON, offset)
+		resErrors.add(NewValidationError(err, ""), "", "invalid_json")
+		resErrors[0].pos.Offset = offset
+		return resErrors
+	}
+	checkJSON(document, v.Type().Elem(), "", "", &resErrors)
+	if err := json.Unmarshal(data, v.Interface()); err != nil && len(resErrors) == 0 {
+		resErrors.add(NewValidationError(fmt.Errorf("%w: %s", ErrTypeMismatch, err), ""), "", "type_mismatch")
+	}
+	return resErrors
+}
+
+// jsonSyntaxError describes err returned by a json.Decoder reading data
+// after reaching offset, and returns the offset err occurred at.
+func jsonSyntaxError(err error, data []byte, offset int64) (int64, error) {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		// Offset counts the offending byte.
+		offset = syntaxErr.Offset - 1
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		err, offset = io.ErrUnexpectedEOF, int64(len(data))
+	}
+	return offset, fmt.Errorf("%w: %s at offset %d", ErrInvalidJSON, err, offset)
+}
+
+// skipSeparators returns the offset of the first token at or after offset.
+func skipSeparators(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// checkJSON reports the parts of document, decoded with UseNumber, that do
+// not fit type t. path and fieldName locate document in the decoded value.
+func checkJSON(document any, t reflect.Type, path, fieldName string, resErrors *Errors) {
+	if document == nil || t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+	mismatch := func(expected string) {
+		err := fmt.Errorf("%w: expected %s, got %s", ErrTypeMismatch, expected, jsonKind(document))
+		resErrors.add(NewValidationError(err, fieldName), path, "type_mismatch")
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		checkJSON(document, t.Elem(), path, fieldName, resErrors)
+	case reflect.Struct:
+		object, ok := document.(map[string]any)
+		if !ok {
+			mismatch("object")
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := lookupJSONField(fields, key)
+			if !ok {
+				resErrors.add(NewValidationError(ErrUnknownField, key), joinPath(path, key), "unknown_field")
+				continue
+			}
+			checkJSON(object[key], field.typ, joinPath(path, field.path), field.name, resErrors)
+		}
+	case reflect.Map:
+		object, ok := document.(map[string]any)
+		if !ok {
+			mismatch("object")
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			checkJSON(object[key], t.Elem(), fmt.Sprintf("%s[%s]", path, key), fieldName, resErrors)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := document.(string); !ok {
+				mismatch("base64 string")
+			}
+			return
+		}
+		array, ok := document.([]any)
+		if !ok {
+			mismatch("array")
+			return
+		}
+		for i, elem := range array {
+			checkJSON(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i), fieldName, resErrors)
+		}
+	case reflect.String:
+		if _, ok := document.(string); !ok {
+			mismatch("string")
+		}
+	case reflect.Bool:
+		if _, ok := document.(bool); !ok {
+			mismatch("boolean")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := document.(json.Number); !ok {
+			mismatch("integer")
+		} else if _, err := strconv.ParseInt(string(n), 10, t.Bits()); errors.Is(err, strconv.ErrRange) {
+			mismatch(fmt.Sprintf("%d-bit integer", t.Bits()))
+		} else if err != nil {
+			mismatch("integer")
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := document.(json.Number); !ok {
+			mismatch("unsigned integer")
+		} else if _, err := strconv.ParseUint(string(n), 10, t.Bits()); errors.Is(err, strconv.ErrRange) {
+			mismatch(fmt.Sprintf("%d-bit unsigned integer", t.Bits()))
+		} else if err != nil {
+			mismatch("unsigned integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := document.(json.Number); !ok {
+			mismatch("number")
+		}
+	}
+}
+
+// jsonKind describes a value decoded by encoding/json with UseNumber.
+func jsonKind(document any) string {
+	switch document := document.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number " + string(document)
+	}
+	return "null"
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// jsonField is a struct field as seen by encoding/json.
+type jsonField struct {
+	key  string
+	name string
+	// path is the dotted Go path to the field, which goes through the
+	// embedded structs it is promoted from.
+	path string
+	typ  reflect.Type
+}
+
+// jsonFields returns the fields encoding/json decodes into for struct type
+// t, following its rules for tags and embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	res := make([]jsonField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		key, _, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && key == "" && fieldType.Kind() == reflect.Struct {
+			for _, promoted := range jsonFields(fieldType) {
+				promoted.path = joinPath(field.Name, promoted.path)
+				res = append(res, promoted)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		res = append(res, jsonField{key: key, name: field.Name, path: field.Name, typ: field.Type})
+	}
+	return res
+}
+
+// lookupJSONField finds the field for key the way encoding/json does:
+// an exact match first, then a case-insensitive one.
+func lookupJSONField(fields []jsonField, key string) (jsonField, bool) {
+	for _, field := range fields {
+		if field.key == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.key, key) {
+			return field, true
+		}
+	}
+	return jsonField{}, false
+}
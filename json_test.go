@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type jsonAddress struct {
+	Zip string `json:"zip" validate:"len:5"`
+}
+
+type jsonBase struct {
+	ID string `json:"id" validate:"len:3"`
+}
+
+type jsonUser struct {
+	jsonBase
+	Name    string            `json:"name" validate:"min:3"`
+	Age     int8              `json:"age"`
+	Score   int               `json:"score" validate:"min:0"`
+	Tags    []string          `json:"tags" validate:"dive|len:2"`
+	Address jsonAddress       `json:"address"`
+	Labels  map[string]uint   `json:"labels"`
+	Created time.Time         `json:"created"`
+	Secret  string            `json:"-"`
+	Extra   map[string]string `json:"extra,omitempty"`
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "valid",
+			doc:  `{"id":"abc","name":"alice","age":30,"tags":["go"],"address":{"zip":"12345"},"created":"2024-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "rules",
+			doc:  `{"id":"abc","name":"al","age":30,"tags":["go","rust"],"address":{"zip":"1"}}`,
+			want: "Name: min validation failed\n" +
+				"Tags[1]: len validation failed\n" +
+				"Address.Zip: len validation failed",
+		},
+		{
+			name: "unknown fields",
+			doc:  `{"id":"abc","name":"alice","age":30,"nick":"al","address":{"zip":"12345","city":"x"},"Secret":"s"}`,
+			want: "Secret: unknown field\n" +
+				"Address.city: unknown field\n" +
+				"nick: unknown field",
+		},
+		{
+			name: "type mismatches",
+			doc:  `{"id":"abc","name":7,"age":300,"tags":"go","labels":{"a":-1},"address":[]}`,
+			want: "Address: type mismatch: expected object, got array\n" +
+				"Age: type mismatch: expected 8-bit integer, got number 300\n" +
+				"Labels[a]: type mismatch: expected unsigned integer, got number -1\n" +
+				"Name: type mismatch: expected string, got number 7\n" +
+				"Tags: type mismatch: expected array, got string",
+		},
+		{
+			name: "case-insensitive keys",
+			doc:  `{"ID":"abc","NAME":"alice","Age":30,"Address":{"ZIP":"12345"}}`,
+		},
+		{
+			name: "syntax error",
+			doc:  `{"name":"alice",}`,
+			want: ": invalid JSON: invalid character '}' looking for beginning of object key string at offset 16",
+		},
+		{
+			name: "truncated",
+			doc:  `{"name":"alice"`,
+			want: ": invalid JSON: unexpected EOF at offset 15",
+		},
+		{
+			name: "trailing data",
+			doc:  `{"id":"abc","name":"alice","age":30} {}`,
+			want: ": invalid JSON: unexpected data after top-level value at offset 37",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v jsonUser
+			err := DecodeAndValidate(strings.NewReader(tt.doc), &v, WithOrder(OrderDeclaration))
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidateErrorKinds(t *testing.T) {
+	var v jsonUser
+	err := DecodeAndValidate(strings.NewReader(`{"id":"abc","name":"alice","age":"x","zzz":1,"address":{"zip":"12345"}}`), &v)
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("got %v", err)
+	}
+	if !errors.Is(errs[0], ErrTypeMismatch) || errs[0].Rule() != "type_mismatch" || errs[0].Field() != "Age" {
+		t.Errorf("first error: %v, rule %q, field %q", errs[0], errs[0].Rule(), errs[0].Field())
+	}
+	if errs[0].Path() != "Age" || errs[1].Path() != "zzz" {
+		t.Errorf("paths: %q, %q", errs[0].Path(), errs[1].Path())
+	}
+	if !errors.Is(errs[1], ErrUnknownField) || errs[1].Rule() != "unknown_field" {
+		t.Errorf("second error: %v, rule %q", errs[1], errs[1].Rule())
+	}
+	if v.Name != "alice" {
+		t.Errorf("Name was not decoded: %+v", v)
+	}
+
+	err = DecodeAndValidate(strings.NewReader(`[`), &v)
+	if !errors.Is(err, ErrInvalidJSON) {
+		t.Fatalf("got %v, want ErrInvalidJSON", err)
+	}
+}
+
+func TestDecodeAndValidateNotStruct(t *testing.T) {
+	var n int
+	for _, v := range []any{jsonUser{}, (*jsonUser)(nil), &n} {
+		if err := DecodeAndValidate(strings.NewReader(`{}`), v); !errors.Is(err, ErrNotStruct) {
+			t.Errorf("%T: got %v, want ErrNotStruct", v, err)
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestDecodeAndValidateReadError(t *testing.T) {
+	var v jsonUser
+	if err := DecodeAndValidate(failingReader{}, &v); err == nil || err.Error() != "boom" {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Position locates a value in a source document.
+type Position struct {
+	// Offset is the number of bytes before the value.
+	Offset int64
+	// Line and Column are 1-based; Column counts bytes.
+	Line   int
+	Column int
+}
+
+// WithJSONSource makes Validate locate each failure in data, the JSON
+// document the validated value was decoded from, and report it as
+// "Servers[2].Port (line 12, column 7): max validation failed". A failure
+// is located at the key of the failing field, or at the nearest enclosing
+// value present in data. DecodeAndValidate locates failures in the
+// document it reads when given WithJSONSource(nil).
+func WithJSONSource(data []byte) Option {
+	return func(o *options) {
+		o.jsonSource = data
+		o.locateJSON = true
+	}
+}
+
+// locateJSON sets the position in data of every error in e, whose paths
+// start at a value of type t.
+func (e Errors) locateJSON(data []byte, t reflect.Type) {
+	index := indexJSON(data)
+	for _, err := range e {
+		if err.rule == "invalid_json" {
+			err.pos = positionOf(data, err.pos.Offset)
+			continue
+		}
+		segments := jsonSegments(splitPath(err.path), t)
+		for n := len(segments); n >= 0; n-- {
+			if offset, ok := index[strings.ToLower(strings.Join(segments[:n], "\x00"))]; ok {
+				err.pos = positionOf(data, offset)
+				break
+			}
+		}
+	}
+}
+
+func positionOf(data []byte, offset int64) Position {
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return Position{Offset: offset, Line: line, Column: column}
+}
+
+// indexJSON maps the path of every value in the JSON document data to the
+// offset of its key, for object members, or of the value itself. A path is
+// made of lowercased object keys and "[i]" array indexes joined by "\x00".
+// Whatever follows a syntax error is left out.
+func indexJSON(data []byte) map[string]int64 {
+	index := make(map[string]int64)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var walk func(path string, offset int64) bool
+	walk = func(path string, offset int64) bool {
+		index[path] = offset
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				keyOffset := skipSeparators(data, dec.InputOffset())
+				key, err := dec.Token()
+				if err != nil {
+					return false
+				}
+				if !walk(joinSegment(path, strings.ToLower(key.(string))), keyOffset) {
+					return false
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if !walk(joinSegment(path, "["+strconv.Itoa(i)+"]"), skipSeparators(data, dec.InputOffset())) {
+					return false
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err == nil
+	}
+	walk("", skipSeparators(data, 0))
+	return index
+}
+
+func joinSegment(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "\x00" + segment
+}
+
+// splitPath splits a path such as "Servers[2].Port" into "Servers", "[2]"
+// and "Port".
+func splitPath(path string) []string {
+	res := make([]string, 0)
+	for path != "" {
+		end := strings.IndexAny(path[1:], ".[") + 1
+		if end == 0 {
+			end = len(path)
+		}
+		res = append(res, path[:end])
+		path = strings.TrimPrefix(path[end:], ".")
+	}
+	return res
+}
+
+// jsonSegments translates the Go path segments of a value of type t into
+// the segments of its path in a JSON document: object keys as written in
+// the `json` tags, and array indexes. Names that are not Go fields of t,
+// such as unknown fields and fields of values held in interfaces, are
+// taken to be JSON keys.
+func jsonSegments(segments []string, t reflect.Type) []string {
+	res := make([]string, 0, len(segments))
+	for len(segments) > 0 {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		segment := segments[0]
+		if strings.HasPrefix(segment, "[") {
+			if t != nil && t.Kind() == reflect.Map {
+				segment = strings.Trim(segment, "[]")
+			}
+			res = append(res, segment)
+			segments = segments[1:]
+			if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				t = t.Elem()
+			} else {
+				t = nil
+			}
+			continue
+		}
+		if field, n, ok := matchJSONField(segments, t); ok {
+			res = append(res, field.key)
+			segments = segments[n:]
+			t = field.typ
+			continue
+		}
+		res = append(res, segment)
+		segments = segments[1:]
+		t = nil
+	}
+	return res
+}
+
+// matchJSONField finds the field of struct type t whose Go path makes up
+// the first n of segments.
+func matchJSONField(segments []string, t reflect.Type) (jsonField, int, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return jsonField{}, 0, false
+	}
+	for _, field := range jsonFields(t) {
+		fieldPath := strings.Split(field.path, ".")
+		if len(fieldPath) <= len(segments) && slices.Equal(fieldPath, segments[:len(fieldPath)]) {
+			return field, len(fieldPath), true
+		}
+	}
+	return jsonField{}, 0, false
+}
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type posServer struct {
+	Host string `json:"host" validate:"min:3"`
+	Port int    `json:"port" validate:"max:9999"`
+}
+
+type posConfig struct {
+	Name    string      `json:"name" validate:"len:4"`
+	Servers []posServer `json:"servers"`
+	Backup  *posServer  `json:"backup,omitempty"`
+	Owner   string      `validate:"required"`
+}
+
+const posDocument = `{
+  "name": "prod-eu",
+  "servers": [
+    {"host": "a.example", "port": 80},
+    {"host": "b",
+     "port": 70000}
+  ],
+  "backup": {"host": "c.example", "port": 99999}
+}`
+
+func TestValidateWithJSONSource(t *testing.T) {
+	cfg := posConfig{
+		Name:    "prod-eu",
+		Servers: []posServer{{Host: "a.example", Port: 80}, {Host: "b", Port: 70000}},
+		Backup:  &posServer{Host: "c.example", Port: 99999},
+	}
+	err := Validate(cfg, WithJSONSource([]byte(posDocument)))
+	want := "Name (line 2, column 3): len validation failed\n" +
+		"Servers[1].Host (line 5, column 6): min validation failed\n" +
+		"Servers[1].Port (line 6, column 6): max validation failed\n" +
+		"Owner (line 1, column 1): required validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatal("not Errors")
+	}
+	if pos, ok := errs[2].Position(); !ok || pos.Offset != int64(strings.Index(posDocument, `"port": 70000`)) {
+		t.Fatalf("got position %+v, %v", pos, ok)
+	}
+}
+
+func TestDecodeAndValidateLocates(t *testing.T) {
+	var cfg posConfig
+	err := DecodeAndValidate(strings.NewReader("{\n  \"name\": \"prod\",\n  \"Owner\": \"x\",\n  \"servers\": [{\"host\": 1}]\n}"), &cfg, WithJSONSource(nil))
+	want := "Servers[0].Host (line 4, column 16): type mismatch: expected string, got number 1"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+
+	err = DecodeAndValidate(strings.NewReader("{\n  \"name\": }"), &cfg, WithJSONSource(nil))
+	if want := " (line 2, column 11): invalid JSON: invalid character '}' looking for beginning of value at offset 12"; err == nil || err.Error() != want {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestPositionWithoutSource(t *testing.T) {
+	err := Validate(posConfig{Name: "prod"})
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("got %v", err)
+	}
+	if _, ok := errs[0].Position(); ok {
+		t.Fatal("got a position without WithJSONSource")
+	}
+}
+
+func TestValidateNilWithJSONSource(t *testing.T) {
+	err := Validate(nil, WithJSONSource([]byte(`{"name": "prod"}`)))
+	if !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+}
+
+func TestIndexJSON(t *testing.T) {
+	data := []byte(`{"A": [1, {"B": 2}], "c": {}}`)
+	index := indexJSON(data)
+	want := map[string]int64{
+		"":              0,
+		"a":             1,
+		"a\x00[0]":      7,
+		"a\x00[1]":      10,
+		"a\x00[1]\x00b": 11,
+		"c":             21,
+	}
+	if !reflect.DeepEqual(index, want) {
+		t.Fatalf("got %q, want %q", index, want)
+	}
+	if index := indexJSON([]byte(`{"a": 1, "b": [`)); index["a"] != 1 || index["b"] != 9 {
+		t.Fatalf("truncated document: got %q", index)
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"", []string{}},
+		{"Name", []string{"Name"}},
+		{"Servers[2].Port", []string{"Servers", "[2]", "Port"}},
+		{"Matrix[0][1]", []string{"Matrix", "[0]", "[1]"}},
+	}
+	for _, tt := range tests {
+		if got := splitPath(tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("splitPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
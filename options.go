@@ -0,0 +1,54 @@
+package validator
+
+// Order selects how failures are arranged in the error returned by Validate.
+type Order int
+
+const (
+	// OrderDeclaration keeps failures in struct field declaration order,
+	// nested structs being reported in place of the field that holds them.
+	OrderDeclaration Order = iota
+	// OrderPath sorts failures lexically by field path and then by rule name.
+	OrderPath
+)
+
+// Option configures a single Validate call.
+type Option func(*options)
+
+type options struct {
+	order      Order
+	syntax     TagSyntax
+	jsonSource []byte
+	locateJSON bool
+	strictXML  bool
+	params     ParamProvider
+	newError   ErrorFactory
+	hints      bool
+	messages   map[string]string
+}
+
+func newOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return options{}
+	}
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return *o
+}
+
+// WithOrder sets the order of failures in the returned error.
+// The default is OrderDeclaration.
+func WithOrder(order Order) Option {
+	return func(o *options) {
+		o.order = order
+	}
+}
+
+// WithTagSyntax sets the grammar used to parse `validate` struct tags.
+// The default is SyntaxNative.
+func WithTagSyntax(syntax TagSyntax) Option {
+	return func(o *options) {
+		o.syntax = syntax
+	}
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUndefinedParam = errors.New("undefined rule parameter")
+
+// ParamProvider resolves the variables used as rule parameters, such as
+// "plan.max_items" in `validate:"max:$plan.max_items"`.
+type ParamProvider interface {
+	LookupParam(name string) (string, bool)
+}
+
+// Params is a ParamProvider backed by a map. A list for in is given as
+// its comma-separated values, e.g. Params{"allowed_regions": "eu,us"}.
+type Params map[string]string
+
+func (p Params) LookupParam(name string) (string, bool) {
+	value, ok := p[name]
+	return value, ok
+}
+
+// WithParams sets the provider that resolves the variables in rule
+// parameters. A parameter made of "$" and a name of letters, digits, "_"
+// and "." names a variable, e.g.
+// `validate:"max:$plan.max_items|in:$allowed_regions"`, while a list such
+// as `in:$USD,$EUR` is taken literally. A variable is resolved each time
+// the rule is checked, and a variable the provider does not define fails
+// the rule with ErrUndefinedParam.
+func WithParams(p ParamProvider) Option {
+	return func(o *options) {
+		o.params = p
+	}
+}
+
+type paramsKey struct{}
+
+// ContextWithParams returns a copy of ctx carrying p, for ValidateContext.
+func ContextWithParams(ctx context.Context, p ParamProvider) context.Context {
+	return context.WithValue(ctx, paramsKey{}, p)
+}
+
+// ValidateContext is Validate with the ParamProvider stored in ctx by
+// ContextWithParams. WithParams among opts takes precedence over it.
+func ValidateContext(ctx context.Context, v any, opts ...Option) error {
+	if p, ok := ctx.Value(paramsKey{}).(ParamProvider); ok {
+		opts = append([]Option{WithParams(p)}, opts...)
+	}
+	return Validate(v, opts...)
+}
+
+// isVariable reports whether the rule parameter value names a variable.
+func isVariable(value string) bool {
+	if len(value) < 2 || value[0] != '$' {
+		return false
+	}
+	for _, c := range []byte(value[1:]) {
+		if !isAlphanumeric(c) && c != '_' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsVariable reports whether the parameter of r names a variable, which
+// Describe leaves unresolved unless WithParams is given.
+func (r Rule) IsVariable() bool {
+	return isVariable(r.Param)
+}
+
+// resolveParam replaces a variable in the parameter of rule with its value
+// and checks that the value is valid for the rule.
+func resolveParam(fieldName string, rule Rule, o *options) (Rule, error) {
+	if !isVariable(rule.Param) {
+		return rule, nil
+	}
+	name := rule.Param[1:]
+	var value string
+	var ok bool
+	if o.params != nil {
+		value, ok = o.params.LookupParam(name)
+	}
+	if !ok {
+		return rule, NewValidationError(fmt.Errorf("%w: $%s", ErrUndefinedParam, name), fieldName)
+	}
+	if rule.Name == "in" {
+		elems := strings.Split(value, ",")
+		for i, elem := range elems {
+			elems[i] = strings.TrimSpace(elem)
+		}
+		value = strings.Join(elems, ",")
+	}
+	if err := checkParam(fieldName, rule.Name, value); err != nil {
+		return rule, NewValidationError(fmt.Errorf("%w: $%s is %q", ErrInvalidValidatorSyntax, name, value), fieldName)
+	}
+	return Rule{Name: rule.Name, Param: value}, nil
+}
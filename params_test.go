@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type paramsOrder struct {
+	Items    int    `validate:"max:$plan.max_items"`
+	Region   string `validate:"in:$allowed_regions"`
+	Currency string `validate:"in:$USD,$EUR"`
+	Code     string `validate:"len:$code_len"`
+}
+
+func TestIsVariable(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"$plan.max_items", true},
+		{"$x", true},
+		{"$", false},
+		{"plan", false},
+		{"$USD,$EUR", false},
+		{"$a b", false},
+		{"$a-b", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isVariable(tt.value); got != tt.want {
+			t.Errorf("isVariable(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWithParams(t *testing.T) {
+	params := Params{
+		"plan.max_items":  "3",
+		"allowed_regions": "eu, us , New York",
+		"code_len":        "2",
+	}
+	valid := paramsOrder{Items: 3, Region: "New York", Currency: "$EUR", Code: "ab"}
+	if err := Validate(valid, WithParams(params)); err != nil {
+		t.Fatalf("valid value: %v", err)
+	}
+
+	invalid := paramsOrder{Items: 4, Region: "NewYork", Currency: "EUR", Code: "abc"}
+	err := Validate(invalid, WithParams(params))
+	want := "Items: max validation failed\n" +
+		"Region: in validation failed\n" +
+		"Currency: in validation failed\n" +
+		"Code: len validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestValidateUndefinedParam(t *testing.T) {
+	v := paramsOrder{Region: "eu", Currency: "$USD", Code: "ab"}
+	err := Validate(v, WithParams(Params{"allowed_regions": "eu", "code_len": "x"}))
+	want := "Items: undefined rule parameter: $plan.max_items\n" +
+		"Code: invalid validator syntax: $code_len is \"x\""
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	if !errors.Is(err, ErrUndefinedParam) || !errors.Is(err, ErrInvalidValidatorSyntax) {
+		t.Fatalf("got %v", err)
+	}
+	if err := Validate(v); !errors.Is(err, ErrUndefinedParam) {
+		t.Fatalf("without params: got %v, want ErrUndefinedParam", err)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	v := paramsOrder{Items: 2, Region: "eu", Currency: "$USD", Code: "ab"}
+	ctx := ContextWithParams(context.Background(), Params{"plan.max_items": "1", "allowed_regions": "eu", "code_len": "2"})
+	if err := ValidateContext(ctx, v); !errors.Is(err, ErrMaxValidationFailed) {
+		t.Fatalf("got %v, want ErrMaxValidationFailed", err)
+	}
+	override := Params{"plan.max_items": "5", "allowed_regions": "eu", "code_len": "2"}
+	if err := ValidateContext(ctx, v, WithParams(override)); err != nil {
+		t.Fatalf("WithParams did not take precedence: %v", err)
+	}
+}
+
+func TestDescribeParams(t *testing.T) {
+	fields, err := Describe(reflect.TypeFor[paramsOrder]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields[0].Rules[0].Param != "$plan.max_items" || !fields[0].Rules[0].IsVariable() {
+		t.Fatalf("unresolved: got %+v", fields[0].Rules)
+	}
+	fields, err = Describe(reflect.TypeFor[paramsOrder](), WithParams(Params{"plan.max_items": "3", "allowed_regions": "eu ,us"}))
+	want := "Code: undefined rule parameter: $code_len"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got %v, want %s", err, want)
+	}
+	if fields[0].Rules[0].Param != "3" || fields[1].Rules[0].Param != "eu,us" || fields[2].Rules[0].Param != "$USD,$EUR" ||
+		fields[0].Rules[0].IsVariable() || fields[2].Rules[0].IsVariable() {
+		t.Fatalf("resolved: got %+v", fields)
+	}
+}
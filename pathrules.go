@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrFilenameValidationFailed = errors.New("filename validation failed")
+	ErrSafePathValidationFailed = errors.New("safepath validation failed")
+	ErrExtValidationFailed      = errors.New("ext validation failed")
+)
+
+// lookalikeSeparators are characters that some systems, or a later
+// normalization step, turn into "/" or "\".
+const lookalikeSeparators = "⁄∕⧸⧹／＼∖﹨"
+
+// isFilename reports whether s is safe to use as a single file name on
+// common file systems and object stores: it is valid UTF-8, holds no path
+// separator, lookalike or percent-encoded separator, control character or
+// character Windows rejects, is not "." or "..", does not end in a dot or
+// space and is not a reserved Windows device name such as "CON" or
+// "com1.txt".
+func isFilename(s string) bool {
+	if s == "" || s == "." || s == ".." || !utf8.ValidString(s) {
+		return false
+	}
+	if strings.HasSuffix(s, ".") || strings.HasSuffix(s, " ") {
+		return false
+	}
+	if strings.ContainsAny(s, `/\<>:"|?*`+lookalikeSeparators) || hasEncodedSeparator(s) {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return !isReservedName(s)
+}
+
+// hasEncodedSeparator reports whether s holds a percent-encoded "/", "\",
+// or "." as used to write "..", e.g. "%2e%2e%2f", which a later decoding
+// step could turn into a path.
+func hasEncodedSeparator(s string) bool {
+	for {
+		i := strings.IndexByte(s, '%')
+		if i < 0 || i+2 >= len(s) {
+			return false
+		}
+		switch strings.ToLower(s[i+1 : i+3]) {
+		case "2f", "5c", "2e", "00":
+			return true
+		}
+		s = s[i+1:]
+	}
+}
+
+// isReservedName reports whether name, without its extensions, is a
+// device name reserved by Windows.
+func isReservedName(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+	base = strings.TrimRight(base, " ")
+	switch len(base) {
+	case 3:
+		for _, reserved := range []string{"CON", "PRN", "AUX", "NUL"} {
+			if strings.EqualFold(base, reserved) {
+				return true
+			}
+		}
+	case 4:
+		digit := base[3]
+		return (strings.EqualFold(base[:3], "COM") || strings.EqualFold(base[:3], "LPT")) && '0' <= digit && digit <= '9'
+	}
+	return false
+}
+
+// isSafePath reports whether s is a relative slash-separated path whose
+// every element is a file name as accepted by isFilename, except that ".."
+// may appear as long as the cleaned path stays below the starting
+// directory, and, if root is not empty, below the directory root.
+func isSafePath(s, root string) bool {
+	if s == "" || strings.HasPrefix(s, "/") {
+		return false
+	}
+	for rest := s; rest != ""; {
+		var element string
+		element, rest, _ = strings.Cut(rest, "/")
+		if element != ".." && element != "." && element != "" && !isFilename(element) {
+			return false
+		}
+	}
+	cleaned := path.Clean(s)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return false
+	}
+	return root == "" || strings.HasPrefix(cleaned, root+"/")
+}
+
+// hasExt reports whether the name s ends in one of the comma-separated
+// extensions exts, compared without regard to case, e.g. "photo.JPG" for
+// "jpg,png". An extension may hold dots, as in "tar.gz". Empty extensions
+// match nothing.
+func hasExt(s, exts string) bool {
+	for {
+		ext, rest, found := strings.Cut(exts, ",")
+		ext = strings.TrimPrefix(ext, ".")
+		if ext != "" && len(s) > len(ext)+1 && s[len(s)-len(ext)-1] == '.' && strings.EqualFold(s[len(s)-len(ext):], ext) {
+			return true
+		}
+		if !found {
+			return false
+		}
+		exts = rest
+	}
+}
@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIsFilename(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"report.pdf", true},
+		{"archive.tar.gz", true},
+		{".profile", true},
+		{"résumé.txt", true},
+		{"100%.txt", true},
+		{"a%2", true},
+		{"console.log", true},
+		{"COM.txt", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{`a\..\b`, false},
+		{"%2e%2e%2f", false},
+		{"%2E%2E", false},
+		{"a%5cb", false},
+		{"a%2Fb", false},
+		{"a%00", false},
+		{"a\u2215b", false},
+		{"a\uFF0Fb", false},
+		{"a\uFF3Cb", false},
+		{"a\u2044b", false},
+		{"name.", false},
+		{"name ", false},
+		{"name. ", false},
+		{"a\x00b", false},
+		{"a\nb", false},
+		{"a:b", false},
+		{"C:", false},
+		{"a?b", false},
+		{`a"b`, false},
+		{"a|b", false},
+		{"a*b", false},
+		{"a<b>", false},
+		{"CON", false},
+		{"con", false},
+		{"CON .txt", false},
+		{"nul.tar.gz", false},
+		{"COM1", false},
+		{"lpt9.txt", false},
+		{"\xff.txt", false},
+	}
+	for _, tt := range tests {
+		if got := isFilename(tt.s); got != tt.want {
+			t.Errorf("isFilename(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestIsSafePath(t *testing.T) {
+	tests := []struct {
+		s, root string
+		want    bool
+	}{
+		{"a/b/c.txt", "", true},
+		{"./a", "", true},
+		{"a//b", "", true},
+		{"a/../b", "", true},
+		{"a/b/../../c", "", true},
+		{"", "", false},
+		{".", "", false},
+		{"..", "", false},
+		{"a/..", "", false},
+		{"../a", "", false},
+		{"a/../../b", "", false},
+		{"/etc/passwd", "", false},
+		{`a\..\b`, "", false},
+		{`..\a`, "", false},
+		{"a/%2e%2e%2fb", "", false},
+		{"%2e%2e/a", "", false},
+		{"a/%5c", "", false},
+		{"a\u2215..\u2215b", "", false},
+		{"a\uFF0F..\uFF0Fb", "", false},
+		{"a/CON .txt", "", false},
+		{"a./b", "", false},
+		{"a /b", "", false},
+		{"uploads/a.txt", "uploads", true},
+		{"uploads/x/../a.txt", "uploads", true},
+		{"uploads", "uploads", false},
+		{"uploads/..", "uploads", false},
+		{"uploads/../a.txt", "uploads", false},
+		{"uploads/../../a.txt", "uploads", false},
+		{"uploads/x/../../secrets", "uploads", false},
+		{"uploads2/a.txt", "uploads", false},
+		{"a.txt", "uploads", false},
+		{"users/1/a.txt", "users/1", true},
+		{"users/1/../2/a.txt", "users/1", false},
+	}
+	for _, tt := range tests {
+		if got := isSafePath(tt.s, tt.root); got != tt.want {
+			t.Errorf("isSafePath(%q, %q) = %v, want %v", tt.s, tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestHasExt(t *testing.T) {
+	tests := []struct {
+		s, exts string
+		want    bool
+	}{
+		{"photo.jpg", "jpg,png", true},
+		{"photo.PNG", "jpg,png", true},
+		{"photo.jpg", ".jpg", true},
+		{"backup.tar.gz", "tar.gz", true},
+		{"backup.gz", "tar.gz", false},
+		{"photo.jpeg", "jpg", false},
+		{"photojpg", "jpg", false},
+		{".jpg", "jpg", false},
+		{"jpg", "jpg", false},
+		{"photo.jpg.exe", "jpg", false},
+		{"name.", "pdf,", false},
+		{"name.", ",pdf", false},
+		{"name.", "pdf,.", false},
+		{"doc.pdf", "pdf,", true},
+	}
+	for _, tt := range tests {
+		if got := hasExt(tt.s, tt.exts); got != tt.want {
+			t.Errorf("hasExt(%q, %q) = %v, want %v", tt.s, tt.exts, got, tt.want)
+		}
+	}
+}
+
+func TestValidateExtEmptyEntry(t *testing.T) {
+	for _, tag := range []string{"ext:pdf,", "ext:,pdf", "ext:pdf,,doc", "ext:.", "ext:,"} {
+		typ := reflect.StructOf([]reflect.StructField{{
+			Name: "Name",
+			Type: reflect.TypeFor[string](),
+			Tag:  reflect.StructTag(`validate:"` + tag + `"`),
+		}})
+		v := reflect.New(typ).Elem()
+		v.Field(0).SetString("name.")
+		err := Validate(v.Interface())
+		if !errors.Is(err, ErrInvalidValidatorSyntax) {
+			t.Errorf("%s: got %v, want ErrInvalidValidatorSyntax", tag, err)
+		}
+	}
+}
+
+type pathUpload struct {
+	Name string `validate:"filename|ext:pdf,doc"`
+	Path string `validate:"safepath:uploads"`
+}
+
+func TestValidatePathRules(t *testing.T) {
+	tests := []struct {
+		name string
+		v    pathUpload
+		want string
+	}{
+		{name: "valid", v: pathUpload{Name: "cv.PDF", Path: "uploads/2024/cv.pdf"}},
+		{
+			name: "traversal",
+			v:    pathUpload{Name: "..%2fcv.pdf", Path: "uploads/../etc/passwd"},
+			want: "Name: filename validation failed\nPath: safepath validation failed",
+		},
+		{
+			name: "extension",
+			v:    pathUpload{Name: "cv.pdf.exe", Path: "uploads/cv"},
+			want: "Name: ext validation failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidSort         = errors.New("invalid sort")
+	ErrSortNotAllowed      = errors.New("field is not sortable")
+	ErrInvalidFilter       = errors.New("invalid filter")
+	ErrFilterNotAllowed    = errors.New("field is not filterable")
+	ErrFilterOpNotAllowed  = errors.New("filter operator is not allowed")
+	defaultFilterOperators = []string{"eq"}
+)
+
+// SortField is one key of a sort parameter such as "-created_at".
+type SortField struct {
+	// Name is the key as given in the query and Field the name of the
+	// struct field it refers to.
+	Name  string
+	Field string
+	Desc  bool
+}
+
+// Filter is one filter parameter such as "filter[age][gte]=18".
+type Filter struct {
+	Name   string
+	Field  string
+	Op     string
+	Values []string
+}
+
+// ListQuery holds the sort and filter parameters of a list request.
+type ListQuery struct {
+	Sort    []SortField
+	Filters []Filter
+}
+
+// ParseListQuery parses and validates the sort and filter parameters in
+// values for listing resources of struct type t:
+//
+//	sort=-created_at,name
+//	filter[status]=active
+//	filter[age][gte]=18
+//	filter[status][in]=draft,review
+//
+// Fields are named by their `form` tag, or their name if they have none.
+// A field can be sorted on if it has a `sortable:"true"` tag and filtered
+// on if it has a `filterable` tag listing the allowed operators, e.g.
+// `filterable:"eq,in,gte,lte"`; an empty list allows "eq" only. Each filter
+// value, or each element of an "in" list, must pass the rules of its field,
+// except for the "like" operator. Other parameters are ignored.
+//
+// Problems are reported as ValidationErrors with the paths "sort" and
+// "filter[name]".
+func ParseListQuery(values url.Values, t reflect.Type, opts ...Option) (ListQuery, error) {
+	if t.Kind() != reflect.Struct {
+		return ListQuery{}, NewValidationError(ErrNotStruct, "")
+	}
+	o := newOptions(opts)
+	var res ListQuery
+	var resErrors Errors
+	if sort, ok := values["sort"]; ok {
+		res.Sort = parseSort(strings.Join(sort, ","), t, &resErrors)
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if filter, ok := parseFilter(key, value, t, &o, &resErrors); ok {
+				res.Filters = append(res.Filters, filter)
+			}
+		}
+	}
+	return res, resErrors.result(o.order)
+}
+
+func parseSort(sort string, t reflect.Type, resErrors *Errors) []SortField {
+	res := make([]SortField, 0)
+	for _, key := range strings.Split(sort, ",") {
+		name := strings.TrimPrefix(strings.TrimPrefix(key, "-"), "+")
+		if name == "" {
+			resErrors.add(NewValidationError(fmt.Errorf("%w: empty key", ErrInvalidSort), "sort"), "sort", "sortable")
+			continue
+		}
+		if slices.ContainsFunc(res, func(s SortField) bool { return s.Name == name }) {
+			resErrors.add(NewValidationError(fmt.Errorf("%w: %q given twice", ErrInvalidSort, name), "sort"), "sort", "sortable")
+			continue
+		}
+		field, ok := lookupFormField(t, name)
+		if !ok || field.Tag.Get("sortable") != "true" {
+			resErrors.add(NewValidationError(fmt.Errorf("%w: %q", ErrSortNotAllowed, name), "sort"), "sort", "sortable")
+			continue
+		}
+		res = append(res, SortField{Name: name, Field: field.path, Desc: strings.HasPrefix(key, "-")})
+	}
+	return res
+}
+
+func parseFilter(key, value string, t reflect.Type, o *options, resErrors *Errors) (Filter, bool) {
+	name, op, ok := parseFilterKey(key)
+	if !ok {
+		resErrors.add(NewValidationError(fmt.Errorf("%w: malformed key %q", ErrInvalidFilter, key), key), key, "filterable")
+		return Filter{}, false
+	}
+	path := "filter[" + name + "]"
+	field, ok := lookupFormField(t, name)
+	operators, filterable := field.Tag.Lookup("filterable")
+	if !ok || !filterable {
+		resErrors.add(NewValidationError(fmt.Errorf("%w: %q", ErrFilterNotAllowed, name), path), path, "filterable")
+		return Filter{}, false
+	}
+	allowed := defaultFilterOperators
+	if operators != "" {
+		allowed = strings.Split(operators, ",")
+	}
+	if !slices.Contains(allowed, op) {
+		resErrors.add(NewValidationError(fmt.Errorf("%w: %q", ErrFilterOpNotAllowed, op), path), path, "filter_operator")
+		return Filter{}, false
+	}
+	filter := Filter{Name: name, Field: field.path, Op: op, Values: []string{value}}
+	if op == "in" {
+		filter.Values = strings.Split(value, ",")
+	}
+	if op == "like" {
+		return filter, true
+	}
+	valid := true
+	for _, value := range filter.Values {
+		fieldValue := reflect.New(field.Type).Elem()
+		if err := setFormValue(fieldValue, []string{value}); err != nil {
+			resErrors.add(NewValidationError(err, path), path, "type_mismatch")
+			valid = false
+			continue
+		}
+		if fieldErrors := checkFieldValue(field.StructField, fieldValue, path, o); len(fieldErrors) > 0 {
+			*resErrors = append(*resErrors, fieldErrors...)
+			valid = false
+		}
+	}
+	return filter, valid
+}
+
+// parseFilterKey splits "filter[name]" and "filter[name][op]" into the
+// field name and the operator, which defaults to "eq".
+func parseFilterKey(key string) (name, op string, ok bool) {
+	rest := strings.TrimPrefix(key, "filter[")
+	name, rest, ok = strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	if rest == "" {
+		return name, "eq", true
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") || len(rest) < 3 {
+		return "", "", false
+	}
+	return name, rest[1 : len(rest)-1], true
+}
+
+// checkFieldValue returns the failures of value against the rules of
+// struct field field, reported at path.
+func checkFieldValue(field reflect.StructField, value reflect.Value, path string, o *options) Errors {
+	var resErrors Errors
+	fieldPath := fieldPath{{name: path}}
+	validateTypeRules(field.Name, value, &fieldPath, o, &resErrors)
+	if tag, ok := field.Tag.Lookup("validate"); ok {
+		validateRules(field.Name, value, -1, tag, o.syntax, &fieldPath, o, &resErrors)
+	}
+	return resErrors
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type queryArticle struct {
+	Title     string `form:"title" sortable:"true" filterable:"eq,like"`
+	Status    string `form:"status" filterable:"eq,in" validate:"in:draft,review,published"`
+	Age       int    `form:"age" sortable:"true" filterable:"gte,lte" validate:"min:0"`
+	CreatedAt string `form:"created_at" sortable:"true"`
+	Author    string `form:"author" filterable:""`
+	Body      string `form:"body"`
+}
+
+func TestParseListQuery(t *testing.T) {
+	values := url.Values{
+		"sort":                {"-created_at,title", "+age"},
+		"filter[status][in]":  {"draft,review"},
+		"filter[age][gte]":    {"18"},
+		"filter[title][like]": {"%go%"},
+		"filter[author]":      {"alice", "bob"},
+		"page":                {"2"},
+	}
+	got, err := ParseListQuery(values, reflect.TypeFor[queryArticle]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ListQuery{
+		Sort: []SortField{
+			{Name: "created_at", Field: "CreatedAt", Desc: true},
+			{Name: "title", Field: "Title"},
+			{Name: "age", Field: "Age"},
+		},
+		Filters: []Filter{
+			{Name: "age", Field: "Age", Op: "gte", Values: []string{"18"}},
+			{Name: "author", Field: "Author", Op: "eq", Values: []string{"alice"}},
+			{Name: "author", Field: "Author", Op: "eq", Values: []string{"bob"}},
+			{Name: "status", Field: "Status", Op: "in", Values: []string{"draft", "review"}},
+			{Name: "title", Field: "Title", Op: "like", Values: []string{"%go%"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseListQueryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  url.Values
+		want    string
+		wantErr error
+	}{
+		{"empty sort key", url.Values{"sort": {"title,"}}, `sort: invalid sort: empty key`, ErrInvalidSort},
+		{"repeated sort key", url.Values{"sort": {"title,-title"}}, `sort: invalid sort: "title" given twice`, ErrInvalidSort},
+		{"not sortable", url.Values{"sort": {"body"}}, `sort: field is not sortable: "body"`, ErrSortNotAllowed},
+		{"unknown sort key", url.Values{"sort": {"nope"}}, `sort: field is not sortable: "nope"`, ErrSortNotAllowed},
+		{"malformed filter", url.Values{"filter[age][gte": {"1"}}, `filter[age][gte: invalid filter: malformed key "filter[age][gte"`, ErrInvalidFilter},
+		{"empty operator", url.Values{"filter[age][]": {"1"}}, `filter[age][]: invalid filter: malformed key "filter[age][]"`, ErrInvalidFilter},
+		{"not filterable", url.Values{"filter[body]": {"x"}}, `filter[body]: field is not filterable: "body"`, ErrFilterNotAllowed},
+		{"operator not allowed", url.Values{"filter[age]": {"1"}}, `filter[age]: filter operator is not allowed: "eq"`, ErrFilterOpNotAllowed},
+		{"type mismatch", url.Values{"filter[age][lte]": {"old"}}, `filter[age]: type mismatch: expected int, got "old"`, ErrTypeMismatch},
+		{"rule", url.Values{"filter[age][gte]": {"-1"}}, `filter[age]: min validation failed`, ErrMinValidationFailed},
+		{"in element", url.Values{"filter[status][in]": {"draft,gone"}}, `filter[status]: in validation failed`, ErrInValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseListQuery(tt.values, reflect.TypeFor[queryArticle]())
+			if err == nil || err.Error() != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %s", err, tt.want)
+			}
+			if len(got.Filters) != 0 {
+				t.Fatalf("got rejected filters %+v", got.Filters)
+			}
+		})
+	}
+}
+
+func TestParseListQueryNotStruct(t *testing.T) {
+	if _, err := ParseListQuery(url.Values{}, reflect.TypeFor[int]()); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+}
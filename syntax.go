@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TagSyntax selects the grammar of `validate` struct tags.
+type TagSyntax int
+
+const (
+	// SyntaxNative is this library's grammar: rules of the form name:value
+	// or name separated by "|", e.g. `validate:"required|min:3|max:20"`.
+	SyntaxNative TagSyntax = iota
+	// SyntaxPlayground accepts the tags of github.com/go-playground/validator,
+	// e.g. `validate:"required,min=3,oneof=a b"`, and maps them onto this
+	// library's rules. Only required, omitempty, dive, email, len, min, max
+	// and oneof are supported; len, min and max on a slice must follow dive.
+	SyntaxPlayground
+)
+
+// nextRule cuts the first rule off tag and returns it translated to this
+// library's rules, together with the rest of tag.
+func nextRule(fieldName, tag string, syntax TagSyntax) (Rule, string, error) {
+	if syntax == SyntaxPlayground {
+		return nextPlaygroundRule(fieldName, tag)
+	}
+	tag, rest, _ := strings.Cut(tag, "|")
+	validator, value, err := checkValidator(fieldName, tag)
+	if err != nil {
+		return Rule{}, "", err
+	}
+	return Rule{Name: validator, Param: value}, rest, nil
+}
+
+func nextPlaygroundRule(fieldName, tag string) (Rule, string, error) {
+	tag, rest, _ := strings.Cut(tag, ",")
+	name, value, _ := strings.Cut(tag, "=")
+	rule := Rule{Name: name, Param: value}
+	switch name {
+	case "required", "omitempty", "dive", "email":
+		if value != "" {
+			return Rule{}, "", NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+		}
+	case "len", "min", "max":
+	case "oneof":
+		rule = Rule{Name: "in", Param: strings.Join(strings.Fields(value), ",")}
+	default:
+		return Rule{}, "", NewValidationError(fmt.Errorf("%w: unsupported tag %q", ErrInvalidValidatorSyntax, name), fieldName)
+	}
+	if err := checkParam(fieldName, rule.Name, rule.Param); err != nil {
+		return Rule{}, "", err
+	}
+	return rule, rest, nil
+}
+
+// TranslateTag rewrites a `validate` tag value written in syntax into
+// SyntaxNative, e.g. "required,min=3,oneof=a b" in SyntaxPlayground
+// into "required|min:3|in:a,b".
+func TranslateTag(tag string, syntax TagSyntax) (string, error) {
+	rules := make([]string, 0)
+	for tag != "" {
+		var rule Rule
+		var err error
+		if rule, tag, err = nextRule("", tag, syntax); err != nil {
+			return "", errors.Unwrap(err)
+		}
+		if isParamless(rule.Name) || rule.Name == "safepath" && rule.Param == "" {
+			rules = append(rules, rule.Name)
+		} else {
+			rules = append(rules, rule.Name+":"+rule.Param)
+		}
+	}
+	return strings.Join(rules, "|"), nil
+}
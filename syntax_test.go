@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTranslateTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "", want: ""},
+		{tag: "required", want: "required"},
+		{tag: "required,min=3,max=20", want: "required|min:3|max:20"},
+		{tag: "omitempty,email", want: "omitempty|email"},
+		{tag: "oneof=a b  c", want: "in:a,b,c"},
+		{tag: "dive,len=2", want: "dive|len:2"},
+		{tag: "required=1", wantErr: true},
+		{tag: "min=x", wantErr: true},
+		{tag: "gte=3", wantErr: true},
+		{tag: "required,uuid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := TranslateTag(tt.tag, SyntaxPlayground)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidValidatorSyntax) {
+					t.Fatalf("got %q, %v; want ErrInvalidValidatorSyntax", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateTagNative(t *testing.T) {
+	got, err := TranslateTag("required|min:3|in:a,b", SyntaxNative)
+	if err != nil || got != "required|min:3|in:a,b" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+}
+
+func TestTranslateTagUnsupported(t *testing.T) {
+	_, err := TranslateTag("required,gte=3", SyntaxPlayground)
+	if err == nil || !strings.Contains(err.Error(), `unsupported tag "gte"`) {
+		t.Fatalf("got %v, want the unsupported tag named", err)
+	}
+}
+
+type playgroundUser struct {
+	Name  string   `validate:"required,min=3,max=10"`
+	Role  string   `validate:"oneof=admin user"`
+	Email string   `validate:"omitempty,email"`
+	Tags  []string `validate:"dive,len=2"`
+}
+
+func TestValidatePlayground(t *testing.T) {
+	valid := playgroundUser{Name: "alice", Role: "user", Tags: []string{"go"}}
+	if err := Validate(valid, WithTagSyntax(SyntaxPlayground)); err != nil {
+		t.Fatalf("valid value: %v", err)
+	}
+
+	invalid := playgroundUser{Name: "al", Role: "root", Email: "x", Tags: []string{"go", "rust"}}
+	err := Validate(invalid, WithTagSyntax(SyntaxPlayground))
+	want := "Name: min validation failed\n" +
+		"Role: in validation failed\n" +
+		"Email: email validation failed\n" +
+		"Tags[1]: len validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestValidatePlaygroundSliceRequiresDive(t *testing.T) {
+	type withSlice struct {
+		Tags []string `validate:"min=1"`
+	}
+	err := Validate(withSlice{Tags: []string{"a"}}, WithTagSyntax(SyntaxPlayground))
+	if !errors.Is(err, ErrInvalidValidatorSyntax) || !strings.Contains(err.Error(), "requires dive") {
+		t.Fatalf("got %v, want min on a slice rejected", err)
+	}
+}
+
+func TestValidateNativeRejectsPlayground(t *testing.T) {
+	if err := Validate(playgroundUser{Name: "alice"}); !errors.Is(err, ErrInvalidValidatorSyntax) {
+		t.Fatalf("got %v, want ErrInvalidValidatorSyntax", err)
+	}
+}
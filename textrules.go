@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"unicode"
+)
+
+var (
+	ErrNoBidiValidationFailed       = errors.New("nobidi validation failed")
+	ErrNoInvisibleValidationFailed  = errors.New("noinvisible validation failed")
+	ErrNoControlValidationFailed    = errors.New("nocontrol validation failed")
+	ErrSingleScriptValidationFailed = errors.New("singlescript validation failed")
+)
+
+// checkString checks field, a string or a slice of strings, with valid.
+func checkString(fieldName string, field reflect.Value, failed error, valid func(string) bool) error {
+	switch field.Kind() {
+	case reflect.String:
+		if !valid(field.String()) {
+			return NewValidationError(failed, fieldName)
+		}
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return NewValidationError(errors.New("there are no strings in the slice"), fieldName)
+		}
+		for i := 0; i < field.Len(); i++ {
+			if !valid(field.Index(i).String()) {
+				return NewValidationError(failed, fieldName)
+			}
+		}
+	default:
+		return NewValidationError(errors.New("not supported type"), fieldName)
+	}
+	return nil
+}
+
+// hasNoBidi reports whether s is free of bidirectional control characters,
+// such as U+202E RIGHT-TO-LEFT OVERRIDE.
+func hasNoBidi(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Bidi_Control, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasNoInvisible reports whether s is free of default ignorable code
+// points, such as U+200B ZERO WIDTH SPACE, which render as nothing.
+func hasNoInvisible(s string) bool {
+	for _, r := range s {
+		if isDefaultIgnorable(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDefaultIgnorable reports whether r has the Default_Ignorable_Code_Point
+// property, derived as Unicode does from the tables of package unicode.
+func isDefaultIgnorable(r rune) bool {
+	if unicode.Is(unicode.White_Space, r) || unicode.Is(unicode.Prepended_Concatenation_Mark, r) ||
+		0xFFF9 <= r && r <= 0xFFFB || 0x13430 <= r && r <= 0x1343F {
+		return false
+	}
+	return unicode.In(r, unicode.Other_Default_Ignorable_Code_Point, unicode.Cf, unicode.Variation_Selector)
+}
+
+// hasNoControl reports whether s is free of control characters, including
+// tabs and line breaks.
+func hasNoControl(s string) bool {
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSingleScript reports whether the letters and marks of s belong to a
+// single script, so that e.g. a Cyrillic "а" cannot pass for a Latin "a".
+// Characters common to all scripts, such as digits and punctuation, go
+// with any script, and Han goes with the scripts written alongside it:
+// Hiragana and Katakana, Hangul, and Bopomofo.
+func isSingleScript(s string) bool {
+	var script string
+	han := false
+	for _, r := range s {
+		name := scriptOf(r)
+		switch name {
+		case "", "Common", "Inherited":
+			continue
+		case "Han":
+			han = true
+			if script != "" && !hanCompatible(script) {
+				return false
+			}
+			continue
+		case "Hiragana", "Katakana":
+			name = "Japanese"
+		}
+		if script == "" {
+			script = name
+		}
+		if name != script || han && !hanCompatible(script) {
+			return false
+		}
+	}
+	return true
+}
+
+func hanCompatible(script string) bool {
+	return script == "Japanese" || script == "Hangul" || script == "Bopomofo"
+}
+
+// scriptRange is a range of runes of the script name.
+type scriptRange struct {
+	lo, hi rune
+	name   string
+}
+
+// scriptRanges returns the ranges of unicode.Scripts sorted by their first
+// rune, with the strided ones split into single runes so that they do not
+// overlap.
+var scriptRanges = sync.OnceValue(func() []scriptRange {
+	res := make([]scriptRange, 0)
+	add := func(lo, hi, stride rune, name string) {
+		if stride == 1 {
+			res = append(res, scriptRange{lo, hi, name})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			res = append(res, scriptRange{r, r, name})
+		}
+	}
+	for name, table := range unicode.Scripts {
+		for _, r := range table.R16 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride), name)
+		}
+		for _, r := range table.R32 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride), name)
+		}
+	}
+	slices.SortFunc(res, func(a, b scriptRange) int {
+		return int(a.lo - b.lo)
+	})
+	return res
+})
+
+// scriptOf returns the name of the script of r, or "" if it has none.
+func scriptOf(r rune) string {
+	if r < 0x80 {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' {
+			return "Latin"
+		}
+		return "Common"
+	}
+	ranges := scriptRanges()
+	i, found := slices.BinarySearchFunc(ranges, r, func(sr scriptRange, r rune) int {
+		return int(sr.lo - r)
+	})
+	if !found {
+		i--
+	}
+	if i < 0 || r > ranges[i].hi {
+		return ""
+	}
+	return ranges[i].name
+}
@@ -0,0 +1,168 @@
+package validator
+
+import (
+	"testing"
+	"unicode"
+)
+
+type textProfile struct {
+	Name    string   `validate:"nobidi|noinvisible|nocontrol|singlescript"`
+	Aliases []string `validate:"singlescript"`
+}
+
+func TestValidateTextRules(t *testing.T) {
+	tests := []struct {
+		name string
+		v    textProfile
+		want string
+	}{
+		{name: "latin", v: textProfile{Name: "Alice 42!"}},
+		{name: "cyrillic", v: textProfile{Name: "Алиса"}},
+		{name: "combining mark", v: textProfile{Name: "José"}},
+		{name: "japanese", v: textProfile{Name: "山田たろう カタカナ"}},
+		{name: "korean", v: textProfile{Name: "김철수 金哲洙"}},
+		{name: "aliases", v: textProfile{Aliases: []string{"bob", "Боб"}}},
+		{
+			name: "bidi override",
+			v:    textProfile{Name: "abc\u202Etxt.exe"},
+			want: "Name: nobidi validation failed\nName: noinvisible validation failed",
+		},
+		{
+			name: "zero width space",
+			v:    textProfile{Name: "ad\u200Bmin"},
+			want: "Name: noinvisible validation failed",
+		},
+		{
+			name: "soft hyphen",
+			v:    textProfile{Name: "ad\u00ADmin"},
+			want: "Name: noinvisible validation failed",
+		},
+		{
+			name: "line break",
+			v:    textProfile{Name: "alice\nbob"},
+			want: "Name: nocontrol validation failed",
+		},
+		{
+			name: "mixed scripts",
+			v:    textProfile{Name: "p\u0430ypal"},
+			want: "Name: singlescript validation failed",
+		},
+		{
+			name: "han with latin",
+			v:    textProfile{Name: "abc山"},
+			want: "Name: singlescript validation failed",
+		},
+		{
+			name: "mixed alias",
+			v:    textProfile{Aliases: []string{"bob", "B\u043Eb"}},
+			want: "Aliases: singlescript validation failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSingleScript(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"", true},
+		{"123 - !?", true},
+		{"hello", true},
+		{"ελληνικά", true},
+		{"中文", true},
+		{"中文ㄅㄆ", true},
+		{"ひらがなカタカナ漢字", true},
+		{"漢字한글", true},
+		{"한글ひらがな", false},
+		{"hello мир", false},
+		{"漢字abc", false},
+		{"abc漢字", false},
+		{"ελληνικάabc", false},
+	}
+	for _, tt := range tests {
+		if got := isSingleScript(tt.s); got != tt.want {
+			t.Errorf("isSingleScript(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestScriptOf(t *testing.T) {
+	for name, table := range unicode.Scripts {
+		for _, r := range table.R16 {
+			for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+				if c >= 0x80 && scriptOf(c) != name {
+					t.Fatalf("scriptOf(%U) = %q, want %q", c, scriptOf(c), name)
+				}
+			}
+		}
+		for _, r := range table.R32 {
+			for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+				if scriptOf(c) != name {
+					t.Fatalf("scriptOf(%U) = %q, want %q", c, scriptOf(c), name)
+				}
+			}
+		}
+	}
+	tests := []struct {
+		r    rune
+		want string
+	}{
+		{'a', "Latin"},
+		{'Z', "Latin"},
+		{'7', "Common"},
+		{' ', "Common"},
+		{'é', "Latin"},
+		{'а', "Cyrillic"},
+		{'\u0301', "Inherited"},
+		{'山', "Han"},
+		{'\U000E01F0', ""},
+		{unicode.MaxRune, ""},
+	}
+	for _, tt := range tests {
+		if got := scriptOf(tt.r); got != tt.want {
+			t.Errorf("scriptOf(%U) = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+}
+
+func TestHasNoInvisible(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"plain text", true},
+		{"tab\tand space", true},
+		{"\u200B", false},
+		{"\u2060", false},
+		{"\uFEFF", false},
+		{"\uFE0F", false},
+		{"\u0600", true},
+		{"\uFFFA", true},
+	}
+	for _, tt := range tests {
+		if got := hasNoInvisible(tt.s); got != tt.want {
+			t.Errorf("hasNoInvisible(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkScriptOf(b *testing.B) {
+	const s = "Алиса José 山田たろう ελληνικά"
+	for i := 0; i < b.N; i++ {
+		isSingleScript(s)
+	}
+}
@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TreeLeaf selects what Errors.Tree puts at the leaves of the tree.
+type TreeLeaf int
+
+const (
+	// LeafRules lists the rules that failed, e.g. []string{"min"}.
+	LeafRules TreeLeaf = iota
+	// LeafMessages lists the messages of the failures without the path,
+	// e.g. []string{"min validation failed"}.
+	LeafMessages
+	// LeafBoth lists a LeafError for each failure.
+	LeafBoth
+)
+
+// LeafError is a leaf of the tree returned by Errors.Tree with LeafBoth.
+type LeafError struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Tree returns the failures in e arranged like the validated value, for
+// form libraries that expect errors shaped like the form data:
+//
+//	{"Address": {"Zip": ["len"]}, "Items": [nil, {"Qty": ["min"]}]}
+//
+// Structs and maps become map[string]any keyed by field name or map key;
+// slices and arrays become []any as long as needed to reach the last
+// failing element, with nil for the elements that passed. The failures of
+// a field are listed as []string, or []LeafError with LeafBoth. A struct
+// that has failures of its own as well as failing fields lists its own
+// under the key "", as does a slice, which is then keyed by index.
+// Failures of the validated value itself are listed under "".
+func (e Errors) Tree(leaf TreeLeaf) map[string]any {
+	return e.tree(leaf, nil)
+}
+
+// JSONTree is Tree with the fields of struct type t, the type of the
+// validated value, named as encoding/json names them.
+func (e Errors) JSONTree(t reflect.Type, leaf TreeLeaf) map[string]any {
+	return e.tree(leaf, func(segments []string) []string {
+		return jsonSegments(segments, t)
+	})
+}
+
+// errorTree is a node of the tree built by Errors.Tree.
+type errorTree struct {
+	leaves   []*ValidationError
+	fields   map[string]*errorTree
+	keys     []string
+	elems    map[int]*errorTree
+	maxIndex int
+}
+
+func (e Errors) tree(leaf TreeLeaf, translate func([]string) []string) map[string]any {
+	root := &errorTree{}
+	for _, err := range e {
+		node := root
+		segments := splitPath(err.path)
+		if translate != nil {
+			segments = translate(segments)
+		}
+		for _, segment := range segments {
+			node = node.child(segment)
+		}
+		node.leaves = append(node.leaves, err)
+	}
+	return root.asMap(leaf)
+}
+
+// child returns the node for segment, a field name, a map key in brackets
+// or an index in brackets, creating it if needed.
+func (t *errorTree) child(segment string) *errorTree {
+	if key, ok := strings.CutPrefix(segment, "["); ok {
+		key = strings.TrimSuffix(key, "]")
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 {
+			if t.elems == nil {
+				t.elems = make(map[int]*errorTree)
+			}
+			if t.elems[i] == nil {
+				t.elems[i] = &errorTree{}
+				t.maxIndex = max(t.maxIndex, i)
+			}
+			return t.elems[i]
+		}
+		segment = key
+	}
+	if t.fields == nil {
+		t.fields = make(map[string]*errorTree)
+	}
+	if t.fields[segment] == nil {
+		t.fields[segment] = &errorTree{}
+		t.keys = append(t.keys, segment)
+	}
+	return t.fields[segment]
+}
+
+func (t *errorTree) render(leaf TreeLeaf) any {
+	switch {
+	case len(t.fields) == 0 && len(t.elems) == 0:
+		return renderLeaves(t.leaves, leaf)
+	case len(t.fields) == 0 && len(t.leaves) == 0:
+		res := make([]any, t.maxIndex+1)
+		for i, elem := range t.elems {
+			res[i] = elem.render(leaf)
+		}
+		return res
+	}
+	return t.asMap(leaf)
+}
+
+func (t *errorTree) asMap(leaf TreeLeaf) map[string]any {
+	res := make(map[string]any, len(t.fields)+len(t.elems)+1)
+	for _, key := range t.keys {
+		res[key] = t.fields[key].render(leaf)
+	}
+	for i, elem := range t.elems {
+		res[strconv.Itoa(i)] = elem.render(leaf)
+	}
+	if len(t.leaves) > 0 {
+		res[""] = renderLeaves(t.leaves, leaf)
+	}
+	return res
+}
+
+func renderLeaves(errs []*ValidationError, leaf TreeLeaf) any {
+	if leaf == LeafBoth {
+		res := make([]LeafError, len(errs))
+		for i, err := range errs {
+			res[i] = LeafError{Rule: err.rule, Message: err.err.Error()}
+		}
+		return res
+	}
+	res := make([]string, len(errs))
+	for i, err := range errs {
+		if leaf == LeafMessages {
+			res[i] = err.err.Error()
+		} else {
+			res[i] = err.rule
+		}
+	}
+	return res
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type treeAddress struct {
+	Zip string `json:"zip" validate:"len:5"`
+}
+
+type treeItem struct {
+	Qty int `json:"qty" validate:"min:1|max:9"`
+}
+
+type treeOrder struct {
+	Name    string      `json:"name" validate:"min:3"`
+	Address treeAddress `json:"address"`
+	Items   []treeItem  `json:"items"`
+	Tags    []string    `json:"tags" validate:"dive|len:2"`
+}
+
+func treeErrors(t *testing.T) Errors {
+	t.Helper()
+	v := treeOrder{
+		Name:    "al",
+		Address: treeAddress{Zip: "1"},
+		Items:   []treeItem{{Qty: 1}, {Qty: 0}, {Qty: 1}},
+		Tags:    []string{"go", "rust"},
+	}
+	errs, ok := Validate(v).(Errors)
+	if !ok {
+		t.Fatal("Validate did not return Errors")
+	}
+	return errs
+}
+
+func TestErrorsTree(t *testing.T) {
+	got := treeErrors(t).Tree(LeafRules)
+	want := map[string]any{
+		"Name":    []string{"min"},
+		"Address": map[string]any{"Zip": []string{"len"}},
+		"Items":   []any{nil, map[string]any{"Qty": []string{"min"}}},
+		"Tags":    []any{nil, []string{"len"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestErrorsJSONTree(t *testing.T) {
+	got, err := json.Marshal(treeErrors(t).JSONTree(reflect.TypeFor[treeOrder](), LeafBoth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"address":{"zip":[{"rule":"len","message":"len validation failed"}]},` +
+		`"items":[null,{"qty":[{"rule":"min","message":"min validation failed"}]}],` +
+		`"name":[{"rule":"min","message":"min validation failed"}],` +
+		`"tags":[null,[{"rule":"len","message":"len validation failed"}]]}`
+	if string(got) != want {
+		t.Fatalf("got %s\nwant %s", got, want)
+	}
+}
+
+func TestErrorsTreeMixed(t *testing.T) {
+	var errs Errors
+	errs.add(NewValidationError(ErrMinValidationFailed, ""), "", "min")
+	errs.add(NewValidationError(ErrRequiredValidationFailed, "Tags"), "Tags", "required")
+	errs.add(NewValidationError(ErrLenValidationFailed, "Tags"), "Tags[2]", "len")
+	errs.add(NewValidationError(ErrInValidationFailed, "Labels"), "Labels[en]", "in")
+	errs.add(NewValidationError(ErrMaxValidationFailed, "Addr"), "Addr", "max")
+	errs.add(NewValidationError(ErrLenValidationFailed, "Zip"), "Addr.Zip", "len")
+	got := errs.Tree(LeafMessages)
+	want := map[string]any{
+		"": []string{"min validation failed"},
+		"Tags": map[string]any{
+			"":  []string{"required validation failed"},
+			"2": []string{"len validation failed"},
+		},
+		"Labels": map[string]any{"en": []string{"in validation failed"}},
+		"Addr": map[string]any{
+			"":    []string{"max validation failed"},
+			"Zip": []string{"len validation failed"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestErrorsTreeEmpty(t *testing.T) {
+	if got := Errors(nil).Tree(LeafRules); len(got) != 0 {
+		t.Fatalf("got %#v", got)
+	}
+}
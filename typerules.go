@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RulesProvider is implemented by named types that declare their own
+// rules, e.g.
+//
+//	type Email string
+//
+//	func (Email) ValidationRules() string { return "email|max:254" }
+//
+// The method is called once per type on its zero value, so it must have
+// a value receiver and return the same rules regardless of the value.
+// Pointer, interface and struct types are not asked for rules, so a field
+// of type *Email is validated against its tag only, and the fields of a
+// struct are validated against their own rules instead.
+type RulesProvider interface {
+	ValidationRules() string
+}
+
+var (
+	rulesProviderType = reflect.TypeFor[RulesProvider]()
+	// typeRulesCache maps a reflect.Type to the rules of that type, or to an
+	// empty string if it has none.
+	typeRulesCache sync.Map
+)
+
+// RegisterTypeRules sets the rules that every exported field of type t,
+// and every element of a slice of t, is validated against in addition to
+// the rules in its own `validate` tag. rules are written in SyntaxNative,
+// e.g. RegisterTypeRules(reflect.TypeFor[Email](), "email|max:254"), and
+// take precedence over a ValidationRules method of t. Struct types are
+// rejected, as Validate checks the fields of a struct instead of the struct
+// itself.
+func RegisterTypeRules(t reflect.Type, rules string) error {
+	if t.Kind() == reflect.Struct {
+		return fmt.Errorf("%w: %s is a struct type", ErrInvalidValidatorSyntax, t)
+	}
+	if _, err := TranslateTag(rules, SyntaxNative); err != nil {
+		return err
+	}
+	typeRulesCache.Store(t, rules)
+	return nil
+}
+
+// typeRules returns the rules registered for t or declared by its
+// ValidationRules method.
+func typeRules(t reflect.Type) string {
+	if rules, ok := typeRulesCache.Load(t); ok {
+		return rules.(string)
+	}
+	rules := ""
+	// The zero value of a pointer or an interface is nil, and structs are
+	// not checked as a whole.
+	kind := t.Kind()
+	if kind != reflect.Pointer && kind != reflect.Interface && kind != reflect.Struct && t.Implements(rulesProviderType) {
+		rules = reflect.Zero(t).Interface().(RulesProvider).ValidationRules()
+	}
+	actual, _ := typeRulesCache.LoadOrStore(t, rules)
+	return actual.(string)
+}
+
+// startsWithOmitEmpty reports whether the first rule in tag is omitempty.
+func startsWithOmitEmpty(fieldName, tag string, syntax TagSyntax) bool {
+	rule, _, err := nextRule(fieldName, tag, syntax)
+	return err == nil && rule.Name == "omitempty"
+}
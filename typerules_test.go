@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type typeRulesEmail string
+
+func (typeRulesEmail) ValidationRules() string { return "email|max:20" }
+
+type typeRulesCode string
+
+type typeRulesUser struct {
+	Email   typeRulesEmail  `validate:"required"`
+	Backup  *typeRulesEmail `validate:"omitempty"`
+	Aliases []typeRulesEmail
+	Code    typeRulesCode
+	Note    typeRulesEmail `validate:"omitempty"`
+}
+
+func init() {
+	if err := RegisterTypeRules(reflect.TypeFor[typeRulesCode](), "len:4"); err != nil {
+		panic(err)
+	}
+}
+
+func TestValidateTypeRules(t *testing.T) {
+	valid := typeRulesUser{Email: "a@example.com", Aliases: []typeRulesEmail{"b@example.com"}, Code: "ab12"}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("valid value: %v", err)
+	}
+
+	invalid := typeRulesUser{Email: "a", Aliases: []typeRulesEmail{"b@example.com", "c"}, Code: "ab"}
+	err := Validate(invalid)
+	want := "Email: email validation failed\n" +
+		"Aliases[1]: email validation failed\n" +
+		"Code: len validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestValidateTypeRulesNilPointer(t *testing.T) {
+	v := typeRulesUser{Email: "a@example.com", Code: "ab12"}
+	if err := Validate(v); err != nil {
+		t.Fatalf("got %v", err)
+	}
+	if rules := typeRules(reflect.TypeFor[*typeRulesEmail]()); rules != "" {
+		t.Fatalf("pointer type rules: got %q, want none", rules)
+	}
+}
+
+func TestDescribeTypeRules(t *testing.T) {
+	fields, err := Describe(reflect.TypeFor[typeRulesUser]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]Rule{
+		"Email":   {{Name: "email"}, {Name: "max", Param: "20"}, {Name: "required"}},
+		"Backup":  {{Name: "omitempty"}},
+		"Aliases": {{Name: "dive"}, {Name: "email"}, {Name: "max", Param: "20"}},
+		"Code":    {{Name: "len", Param: "4"}},
+		"Note":    {{Name: "omitempty"}, {Name: "email"}, {Name: "max", Param: "20"}},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d: %+v", len(fields), len(want), fields)
+	}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.Rules, want[field.Path]) {
+			t.Errorf("%s: got %v, want %v", field.Path, field.Rules, want[field.Path])
+		}
+	}
+}
+
+func TestRegisterTypeRulesInvalid(t *testing.T) {
+	type unregistered string
+	err := RegisterTypeRules(reflect.TypeFor[unregistered](), "min=3")
+	if !errors.Is(err, ErrInvalidValidatorSyntax) {
+		t.Fatalf("got %v, want ErrInvalidValidatorSyntax", err)
+	}
+	if rules := typeRules(reflect.TypeFor[unregistered]()); rules != "" {
+		t.Fatalf("rejected rules were registered: %q", rules)
+	}
+}
+
+type typeRulesStruct struct {
+	Zip string `validate:"len:5"`
+}
+
+func (typeRulesStruct) ValidationRules() string { return "required" }
+
+type typeRulesHolder struct {
+	Provider RulesProvider
+	Address  typeRulesStruct
+}
+
+func TestValidateTypeRulesInterfaceAndStruct(t *testing.T) {
+	if err := Validate(typeRulesHolder{Address: typeRulesStruct{Zip: "12345"}}); err != nil {
+		t.Fatalf("got %v", err)
+	}
+	err := Validate(typeRulesHolder{Provider: typeRulesEmail("a"), Address: typeRulesStruct{Zip: "1"}})
+	if err == nil || err.Error() != "Address.Zip: len validation failed" {
+		t.Fatalf("got %v", err)
+	}
+	for _, typ := range []reflect.Type{rulesProviderType, reflect.TypeFor[typeRulesStruct]()} {
+		if rules := typeRules(typ); rules != "" {
+			t.Errorf("%s: got rules %q, want none", typ, rules)
+		}
+	}
+}
+
+func TestRegisterTypeRulesStruct(t *testing.T) {
+	err := RegisterTypeRules(reflect.TypeFor[typeRulesStruct](), "required")
+	if !errors.Is(err, ErrInvalidValidatorSyntax) {
+		t.Fatalf("got %v, want ErrInvalidValidatorSyntax", err)
+	}
+}
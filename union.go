@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+var (
+	ErrUnknownVariant  = errors.New("unknown variant")
+	ErrVariantMismatch = errors.New("value does not match variant")
+)
+
+// union describes a field holding one of several struct types, selected by
+// the value of a sibling discriminator field.
+type union struct {
+	discriminator reflect.StructField
+	variants      map[string]reflect.Type
+}
+
+var (
+	unionsMu sync.Mutex
+	// unions maps a struct type to a map[int]*union keyed by field index.
+	// The inner maps are never modified once stored.
+	unions sync.Map
+)
+
+// RegisterUnion declares that field of struct type t holds a value whose
+// type depends on the discriminator field next to it, e.g.
+//
+//	type Event struct {
+//		Type string
+//		Data any
+//	}
+//
+//	validator.RegisterUnion(reflect.TypeFor[Event](), "Data", "Type", map[string]reflect.Type{
+//		"created": reflect.TypeFor[Created](),
+//		"deleted": reflect.TypeFor[Deleted](),
+//	})
+//
+// field must be an interface or a byte slice such as json.RawMessage, the
+// discriminator a string or an integer, and the variants struct types.
+// Validate then checks the value of field against the rules of the variant
+// named by the discriminator. A field holding the variant or a pointer to
+// it is checked as is, and nil is left to required; JSON, given as a byte
+// slice, a string or a map[string]any, is first decoded into a new
+// variant. A zero field tagged omitempty is not checked at all.
+func RegisterUnion(t reflect.Type, field, discriminator string, variants map[string]reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	dataField, ok := t.FieldByName(field)
+	if !ok || len(dataField.Index) != 1 {
+		return fmt.Errorf("%s has no field %s", t, field)
+	}
+	if !isUnionKind(dataField.Type) {
+		return fmt.Errorf("field %s of %s must be an interface or a byte slice", field, t)
+	}
+	discriminatorField, ok := t.FieldByName(discriminator)
+	if !ok || len(discriminatorField.Index) != 1 {
+		return fmt.Errorf("%s has no field %s", t, discriminator)
+	}
+	if kind := discriminatorField.Type.Kind(); kind != reflect.String && (kind < reflect.Int || kind > reflect.Int64) {
+		return fmt.Errorf("discriminator %s of %s must be a string or an integer", discriminator, t)
+	}
+	u := &union{
+		discriminator: discriminatorField,
+		variants:      make(map[string]reflect.Type, len(variants)),
+	}
+	for name, variant := range variants {
+		if variant.Kind() != reflect.Struct {
+			return fmt.Errorf("variant %q of %s is not a struct", name, t)
+		}
+		u.variants[name] = variant
+	}
+	unionsMu.Lock()
+	defer unionsMu.Unlock()
+	fields := make(map[int]*union)
+	if old, ok := unions.Load(t); ok {
+		for i, u := range old.(map[int]*union) {
+			fields[i] = u
+		}
+	}
+	fields[dataField.Index[0]] = u
+	unions.Store(t, fields)
+	return nil
+}
+
+func isUnionKind(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// unionOf returns the union registered for field i of struct type t.
+func unionOf(t reflect.Type, i int) *union {
+	if !isUnionKind(t.Field(i).Type) {
+		return nil
+	}
+	fields, ok := unions.Load(t)
+	if !ok {
+		return nil
+	}
+	return fields.(map[int]*union)[i]
+}
+
+// validateUnion validates field of structValue, which path points to, as
+// the variant selected by u's discriminator.
+func validateUnion(structValue, field reflect.Value, u *union, path *fieldPath, o *options, resErrors *Errors) {
+	discriminator := structValue.FieldByIndex(u.discriminator.Index)
+	name := discriminator.String()
+	if discriminator.CanInt() {
+		name = strconv.FormatInt(discriminator.Int(), 10)
+	}
+	variant, ok := u.variants[name]
+	if !ok {
+		resErrors.fail(NewValidationError(ErrUnknownVariant, u.discriminator.Name), path.sibling(u.discriminator.Name), Rule{Name: "union"}, discriminator, o)
+		return
+	}
+	if field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return
+		}
+		field = field.Elem()
+	}
+	if field.Kind() == reflect.Pointer && field.Type().Elem() == variant {
+		if field.IsNil() {
+			return
+		}
+		field = field.Elem()
+	}
+	if field.Type() != variant {
+		value, err := decodeVariant(field, variant)
+		if err != nil {
+			resErrors.fail(NewValidationError(fmt.Errorf("%w %q: %s", ErrVariantMismatch, name, err), (*path)[len(*path)-1].name), path.String(), Rule{Name: "union"}, field, o)
+			return
+		}
+		field = value
+	}
+	validateValue(field, path, o, resErrors)
+}
+
+// decodeVariant decodes JSON held by v into a new value of type variant.
+func decodeVariant(v reflect.Value, variant reflect.Type) (reflect.Value, error) {
+	var data []byte
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		data = v.Bytes()
+	case v.Kind() == reflect.String:
+		data = []byte(v.String())
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		var err error
+		if data, err = json.Marshal(v.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("unexpected %s", v.Type())
+	}
+	res := reflect.New(variant)
+	if err := json.Unmarshal(data, res.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return res.Elem(), nil
+}
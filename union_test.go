@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type unionCreated struct {
+	ID string `validate:"len:3"`
+}
+
+type unionDeleted struct {
+	Reason string `validate:"min:5"`
+}
+
+type unionEvent struct {
+	Type string
+	Data any
+}
+
+type unionRawEvent struct {
+	Kind int
+	Data json.RawMessage `validate:"omitempty"`
+}
+
+func init() {
+	variants := map[string]reflect.Type{
+		"created": reflect.TypeFor[unionCreated](),
+		"deleted": reflect.TypeFor[unionDeleted](),
+	}
+	if err := RegisterUnion(reflect.TypeFor[unionEvent](), "Data", "Type", variants); err != nil {
+		panic(err)
+	}
+	rawVariants := map[string]reflect.Type{
+		"1": reflect.TypeFor[unionCreated](),
+	}
+	if err := RegisterUnion(reflect.TypeFor[unionRawEvent](), "Data", "Kind", rawVariants); err != nil {
+		panic(err)
+	}
+}
+
+func TestValidateUnion(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    string
+		wantErr error
+	}{
+		{name: "value", v: unionEvent{Type: "created", Data: unionCreated{ID: "abc"}}},
+		{name: "pointer", v: unionEvent{Type: "created", Data: &unionCreated{ID: "abc"}}},
+		{name: "nil", v: unionEvent{Type: "created"}},
+		{name: "nil pointer", v: unionEvent{Type: "created", Data: (*unionCreated)(nil)}},
+		{name: "map", v: unionEvent{Type: "deleted", Data: map[string]any{"Reason": "spam!"}}},
+		{
+			name:    "invalid value",
+			v:       unionEvent{Type: "created", Data: unionCreated{ID: "a"}},
+			want:    "Data.ID: len validation failed",
+			wantErr: ErrLenValidationFailed,
+		},
+		{
+			name:    "invalid json",
+			v:       unionEvent{Type: "deleted", Data: `{"Reason":"no"}`},
+			want:    "Data.Reason: min validation failed",
+			wantErr: ErrMinValidationFailed,
+		},
+		{
+			name:    "unknown variant",
+			v:       unionEvent{Type: "updated", Data: unionCreated{ID: "abc"}},
+			want:    "Type: unknown variant",
+			wantErr: ErrUnknownVariant,
+		},
+		{
+			name:    "mismatch",
+			v:       unionEvent{Type: "created", Data: 42},
+			want:    `Data: value does not match variant "created": unexpected int`,
+			wantErr: ErrVariantMismatch,
+		},
+		{name: "raw", v: unionRawEvent{Kind: 1, Data: json.RawMessage(`{"ID":"abc"}`)}},
+		{
+			name:    "invalid raw",
+			v:       unionRawEvent{Kind: 1, Data: json.RawMessage(`{"ID":"x"}`)},
+			want:    "Data.ID: len validation failed",
+			wantErr: ErrLenValidationFailed,
+		},
+		{name: "omitempty", v: unionRawEvent{Kind: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) || err.Error() != tt.want {
+				t.Fatalf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterUnionErrors(t *testing.T) {
+	type event struct {
+		Type  string
+		Flag  bool
+		Data  any
+		Count int
+	}
+	variants := map[string]reflect.Type{"a": reflect.TypeFor[unionCreated]()}
+	tests := []struct {
+		name          string
+		t             reflect.Type
+		field         string
+		discriminator string
+		variants      map[string]reflect.Type
+	}{
+		{"not a struct", reflect.TypeFor[int](), "Data", "Type", variants},
+		{"missing field", reflect.TypeFor[event](), "Missing", "Type", variants},
+		{"scalar field", reflect.TypeFor[event](), "Count", "Type", variants},
+		{"missing discriminator", reflect.TypeFor[event](), "Data", "Missing", variants},
+		{"bool discriminator", reflect.TypeFor[event](), "Data", "Flag", variants},
+		{"scalar variant", reflect.TypeFor[event](), "Data", "Type", map[string]reflect.Type{"a": reflect.TypeFor[string]()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterUnion(tt.t, tt.field, tt.discriminator, tt.variants); err == nil {
+				t.Fatal("got nil error")
+			}
+		})
+	}
+}
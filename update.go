@@ -0,0 +1,171 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrImmutableValidationFailed  = errors.New("immutable validation failed")
+	ErrMonotonicValidationFailed  = errors.New("monotonic validation failed")
+	ErrTransitionValidationFailed = errors.New("transition validation failed")
+)
+
+// ValidateUpdate validates next like Validate and additionally checks the
+// rules that compare it with prev, the stored value it replaces:
+//
+//	immutable                           the field must not change
+//	monotonic                           the field must increase
+//	transition:draft>review,review>published
+//	                                    the field may only change along
+//	                                    the listed from>to pairs
+//
+// prev and next must be structs of the same type. Validate ignores these
+// rules. immutable applies to fields of any type and uses an Equal method
+// if the type has one, as time.Time does; monotonic applies to numbers,
+// strings and types with a Compare method; transition applies to strings
+// and integers. Fields in slices of structs are compared by index for the
+// elements present in both values, and only fields reachable through
+// exported fields are compared.
+func ValidateUpdate(prev, next any, opts ...Option) error {
+	o := newOptions(opts)
+	oldValue, newValue := reflect.ValueOf(prev), reflect.ValueOf(next)
+	resErrors := validateErrors(newValue, &o)
+	if oldValue.Kind() != reflect.Struct || newValue.Kind() != reflect.Struct {
+		return resErrors.result(o.order)
+	}
+	if oldValue.Type() != newValue.Type() {
+		err := fmt.Errorf("%w: cannot compare %s with %s", ErrNotStruct, oldValue.Type(), newValue.Type())
+		resErrors.add(NewValidationError(err, ""), "", "")
+		return resErrors.result(o.order)
+	}
+	var path fieldPath
+	validateUpdateValue(oldValue, newValue, &path, &o, &resErrors)
+	return resErrors.result(o.order)
+}
+
+func validateUpdateValue(oldValue, newValue reflect.Value, path *fieldPath, o *options, resErrors *Errors) {
+	for i := 0; i < newValue.NumField(); i++ {
+		structField := newValue.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		*path = append(*path, pathSegment{name: structField.Name})
+		oldField, newField := oldValue.Field(i), newValue.Field(i)
+		if tag, ok := structField.Tag.Lookup("validate"); ok {
+			validateUpdateRules(structField.Name, oldField, newField, tag, path, o, resErrors)
+		}
+		switch {
+		case newField.Kind() == reflect.Struct:
+			validateUpdateValue(oldField, newField, path, o, resErrors)
+		case (newField.Kind() == reflect.Slice || newField.Kind() == reflect.Array) && newField.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < min(oldField.Len(), newField.Len()); j++ {
+				*path = append(*path, pathSegment{index: j})
+				validateUpdateValue(oldField.Index(j), newField.Index(j), path, o, resErrors)
+				*path = (*path)[:len(*path)-1]
+			}
+		}
+		*path = (*path)[:len(*path)-1]
+	}
+}
+
+// validateUpdateRules checks the rules in tag that compare oldField with
+// newField. Syntax errors are left to validateRules, which reports them.
+func validateUpdateRules(fieldName string, oldField, newField reflect.Value, tag string, path *fieldPath, o *options, resErrors *Errors) {
+	for tag != "" {
+		var rule Rule
+		var err error
+		rule, tag, err = nextRule(fieldName, tag, o.syntax)
+		if err != nil {
+			return
+		}
+		if rule.Name == "transition" {
+			if rule, err = resolveParam(fieldName, rule, o); err != nil {
+				resErrors.fail(err, path.String(), rule, newField, o)
+				continue
+			}
+		}
+		switch rule.Name {
+		case "omitempty":
+			if newField.IsZero() {
+				return
+			}
+		case "dive":
+			return
+		case "immutable":
+			err = checkImmutable(fieldName, oldField, newField)
+		case "monotonic":
+			err = checkMonotonic(fieldName, oldField, newField)
+		case "transition":
+			err = checkTransition(fieldName, oldField, newField, rule.Param)
+		}
+		if err != nil {
+			resErrors.fail(err, path.String(), rule, newField, o)
+		}
+	}
+}
+
+func checkImmutable(fieldName string, oldField, newField reflect.Value) error {
+	if equal := newField.MethodByName("Equal"); equal.IsValid() && equal.Type().NumIn() == 1 && equal.Type().In(0) == newField.Type() &&
+		equal.Type().NumOut() == 1 && equal.Type().Out(0).Kind() == reflect.Bool {
+		if !equal.Call([]reflect.Value{oldField})[0].Bool() {
+			return NewValidationError(ErrImmutableValidationFailed, fieldName)
+		}
+		return nil
+	}
+	if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+		return NewValidationError(ErrImmutableValidationFailed, fieldName)
+	}
+	return nil
+}
+
+func checkMonotonic(fieldName string, oldField, newField reflect.Value) error {
+	var increased bool
+	switch {
+	case newField.CanInt():
+		increased = newField.Int() > oldField.Int()
+	case newField.CanUint():
+		increased = newField.Uint() > oldField.Uint()
+	case newField.CanFloat():
+		increased = newField.Float() > oldField.Float()
+	case newField.Kind() == reflect.String:
+		increased = newField.String() > oldField.String()
+	default:
+		compare := newField.MethodByName("Compare")
+		if !compare.IsValid() || compare.Type().NumIn() != 1 || compare.Type().In(0) != newField.Type() ||
+			compare.Type().NumOut() != 1 || compare.Type().Out(0).Kind() != reflect.Int {
+			return NewValidationError(errors.New("not supported type"), fieldName)
+		}
+		increased = compare.Call([]reflect.Value{oldField})[0].Int() > 0
+	}
+	if !increased {
+		return NewValidationError(ErrMonotonicValidationFailed, fieldName)
+	}
+	return nil
+}
+
+func checkTransition(fieldName string, oldField, newField reflect.Value, tag string) error {
+	var from, to string
+	switch {
+	case newField.CanInt():
+		from, to = strconv.FormatInt(oldField.Int(), 10), strconv.FormatInt(newField.Int(), 10)
+	case newField.CanUint():
+		from, to = strconv.FormatUint(oldField.Uint(), 10), strconv.FormatUint(newField.Uint(), 10)
+	case newField.Kind() == reflect.String:
+		from, to = oldField.String(), newField.String()
+	default:
+		return NewValidationError(errors.New("not supported type"), fieldName)
+	}
+	if from == to {
+		return nil
+	}
+	for _, transition := range strings.Split(tag, ",") {
+		if transition == from+">"+to {
+			return nil
+		}
+	}
+	return NewValidationError(fmt.Errorf("%w: %q to %q", ErrTransitionValidationFailed, from, to), fieldName)
+}
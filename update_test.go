@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type updateLine struct {
+	SKU string `validate:"immutable"`
+}
+
+type updateDoc struct {
+	ID        string    `validate:"immutable|len:3"`
+	Version   int       `validate:"monotonic"`
+	Status    string    `validate:"transition:draft>review,review>published,review>draft"`
+	Stage     uint      `validate:"omitempty|transition:1>2"`
+	CreatedAt time.Time `validate:"immutable"`
+	UpdatedAt time.Time `validate:"monotonic"`
+	Lines     []updateLine
+	Meta      struct {
+		Owner string `validate:"immutable"`
+	}
+	internal int
+}
+
+func TestValidateUpdate(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := updateDoc{
+		ID: "abc", Version: 1, Status: "draft", Stage: 1, CreatedAt: created, UpdatedAt: created,
+		Lines: []updateLine{{SKU: "a"}, {SKU: "b"}},
+	}
+	old.Meta.Owner = "alice"
+
+	valid := old
+	valid.Version = 2
+	valid.Status = "review"
+	valid.Stage = 2
+	valid.CreatedAt = created.In(time.FixedZone("CET", 3600))
+	valid.UpdatedAt = created.Add(time.Hour)
+	valid.Lines = []updateLine{{SKU: "a"}, {SKU: "b"}, {SKU: "c"}}
+	valid.internal = 5
+	if err := ValidateUpdate(old, valid); err != nil {
+		t.Fatalf("valid update: %v", err)
+	}
+
+	invalid := old
+	invalid.ID = "xyz"
+	invalid.Version = 1
+	invalid.Status = "published"
+	invalid.Stage = 0
+	invalid.CreatedAt = created.Add(time.Second)
+	invalid.UpdatedAt = created.Add(-time.Hour)
+	invalid.Lines = []updateLine{{SKU: "a"}, {SKU: "x"}}
+	invalid.Meta.Owner = "bob"
+	err := ValidateUpdate(old, invalid)
+	want := "ID: immutable validation failed\n" +
+		"Version: monotonic validation failed\n" +
+		"Status: transition validation failed: \"draft\" to \"published\"\n" +
+		"CreatedAt: immutable validation failed\n" +
+		"UpdatedAt: monotonic validation failed\n" +
+		"Lines[1].SKU: immutable validation failed\n" +
+		"Meta.Owner: immutable validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+	if !errors.Is(err, ErrTransitionValidationFailed) {
+		t.Fatalf("got %v, want ErrTransitionValidationFailed", err)
+	}
+}
+
+func TestValidateUpdateAlsoValidates(t *testing.T) {
+	old := updateDoc{ID: "abc", Status: "draft"}
+	if err := Validate(updateDoc{ID: "xyz", Status: "gone"}); err != nil {
+		t.Fatalf("Validate checked update rules: %v", err)
+	}
+	err := ValidateUpdate(old, updateDoc{ID: "ab", Version: 1, Status: "draft"})
+	want := "ID: len validation failed\n" +
+		"ID: immutable validation failed\n" +
+		"UpdatedAt: monotonic validation failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
+
+func TestValidateUpdateTypes(t *testing.T) {
+	type other struct{ ID string }
+	err := ValidateUpdate(other{}, updateDoc{ID: "abc"})
+	if !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("got %v, want ErrNotStruct", err)
+	}
+	type unsupported struct {
+		Tags []string `validate:"monotonic"`
+		Flag bool     `validate:"transition:false>true"`
+	}
+	err = ValidateUpdate(unsupported{}, unsupported{Tags: []string{"a"}, Flag: true})
+	want := "Tags: not supported type\n" +
+		"Flag: not supported type"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got:\n%v\nwant:\n%s", err, want)
+	}
+}
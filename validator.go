@@ -3,9 +3,11 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 var (
@@ -16,28 +18,78 @@ var (
 	ErrInValidationFailed          = errors.New("in validation failed")
 	ErrMaxValidationFailed         = errors.New("max validation failed")
 	ErrMinValidationFailed         = errors.New("min validation failed")
+	ErrRequiredValidationFailed    = errors.New("required validation failed")
+	ErrEmailValidationFailed       = errors.New("email validation failed")
+	ErrEnumValidationFailed        = errors.New("enum validation failed")
 )
 
 type ValidationError struct {
-	field string
-	err   error
+	field  string
+	path   string
+	rule   string
+	pos    Position
+	params map[string]any
+	err    error
+	// sentinel is the error err replaced when it was built by an
+	// ErrorFactory.
+	sentinel error
 }
 
 func NewValidationError(err error, field string) error {
 	return &ValidationError{
 		field: field,
+		path:  field,
 		err:   err,
 	}
 }
 
 func (e *ValidationError) Error() string {
-	return fmt.Sprintf("%s: %s", e.field, e.err)
+	if e.pos.Line > 0 {
+		return fmt.Sprintf("%s (line %d, column %d): %s", e.path, e.pos.Line, e.pos.Column, e.err)
+	}
+	return fmt.Sprintf("%s: %s", e.path, e.err)
+}
+
+// Field returns the name of the struct field that failed validation.
+func (e *ValidationError) Field() string {
+	return e.field
+}
+
+// Path returns the dotted path to the field from the validated value,
+// e.g. "Address.Zip".
+func (e *ValidationError) Path() string {
+	return e.path
+}
+
+// Rule returns the name of the validator that produced the error,
+// e.g. "len", or an empty string if the error is not tied to a rule.
+func (e *ValidationError) Rule() string {
+	return e.rule
+}
+
+// Position returns where the failing value is in the source document, if
+// it was located with WithJSONSource.
+func (e *ValidationError) Position() (Position, bool) {
+	return e.pos, e.pos.Line > 0
+}
+
+// Params returns the parameters the failure was reported with by
+// Errors.Add, or the hints set by WithHints, or nil.
+func (e *ValidationError) Params() map[string]any {
+	return e.params
 }
 
 func (e *ValidationError) Unwrap() error {
 	return e.err
 }
 
+// Is reports whether target is the error of the failure before it was
+// replaced by an ErrorFactory, so that errors.Is matches the package
+// sentinels whatever the factory returns.
+func (e *ValidationError) Is(target error) bool {
+	return e.sentinel != nil && errors.Is(e.sentinel, target)
+}
+
 func checkLength(fieldName string, field reflect.Value, tag string) error {
 	length, err := strconv.Atoi(tag)
 	if err != nil {
@@ -49,12 +101,11 @@ func checkLength(fieldName string, field reflect.Value, tag string) error {
 			return NewValidationError(ErrLenValidationFailed, fieldName)
 		}
 	case reflect.Slice:
-		checkedStrings, ok := field.Interface().([]string)
-		if !ok {
+		if field.Type().Elem().Kind() != reflect.String {
 			return NewValidationError(errors.New("there are no strings in the slice"), fieldName)
 		}
-		for _, checkedString := range checkedStrings {
-			if len(checkedString) != length {
+		for i := 0; i < field.Len(); i++ {
+			if len(field.Index(i).String()) != length {
 				return NewValidationError(ErrLenValidationFailed, fieldName)
 			}
 		}
@@ -65,20 +116,24 @@ func checkLength(fieldName string, field reflect.Value, tag string) error {
 }
 
 func checkIn(fieldName string, field reflect.Value, tag string) error {
-	checkValues := make(map[string]struct{})
-	for _, char := range strings.Split(tag, ",") {
-		checkValues[char] = struct{}{}
-	}
-	value := ""
+	var buf [20]byte
+	var number []byte
+	var value string
 	if field.CanInt() {
-		value = strconv.Itoa(int(field.Int()))
+		number = strconv.AppendInt(buf[:0], field.Int(), 10)
 	} else {
 		value = field.String()
 	}
-	if _, ok := checkValues[value]; ok {
-		return nil
+	for {
+		checkValue, rest, found := strings.Cut(tag, ",")
+		if number != nil && string(number) == checkValue || number == nil && value == checkValue {
+			return nil
+		}
+		if !found {
+			return NewValidationError(ErrInValidationFailed, fieldName)
+		}
+		tag = rest
 	}
-	return NewValidationError(ErrInValidationFailed, fieldName)
 }
 
 func checkMin(fieldName string, field reflect.Value, tag string) error {
@@ -95,14 +150,14 @@ func checkMin(fieldName string, field reflect.Value, tag string) error {
 	case reflect.Slice:
 		switch field.Type().Elem().Kind() {
 		case reflect.Int:
-			for _, num := range field.Interface().([]int) {
-				if num < checkValue {
+			for i := 0; i < field.Len(); i++ {
+				if int(field.Index(i).Int()) < checkValue {
 					return NewValidationError(ErrMinValidationFailed, fieldName)
 				}
 			}
 		case reflect.String:
-			for _, str := range field.Interface().([]string) {
-				if len(str) < checkValue {
+			for i := 0; i < field.Len(); i++ {
+				if len(field.Index(i).String()) < checkValue {
 					return NewValidationError(ErrMinValidationFailed, fieldName)
 				}
 			}
@@ -118,7 +173,7 @@ func checkMin(fieldName string, field reflect.Value, tag string) error {
 func checkMax(fieldName string, field reflect.Value, tag string) error {
 	checkValue, _ := strconv.Atoi(tag)
 	switch field.Kind() {
-	case reflect.Int:
+	case reflect.Int, reflect.Int64:
 		if int(field.Int()) > checkValue {
 			return NewValidationError(ErrMaxValidationFailed, fieldName)
 		}
@@ -129,14 +184,14 @@ func checkMax(fieldName string, field reflect.Value, tag string) error {
 	case reflect.Slice:
 		switch field.Type().Elem().Kind() {
 		case reflect.Int:
-			for _, num := range field.Interface().([]int) {
-				if num > checkValue {
+			for i := 0; i < field.Len(); i++ {
+				if int(field.Index(i).Int()) > checkValue {
 					return NewValidationError(ErrMaxValidationFailed, fieldName)
 				}
 			}
 		case reflect.String:
-			for _, str := range field.Interface().([]string) {
-				if len(str) > checkValue {
+			for i := 0; i < field.Len(); i++ {
+				if len(field.Index(i).String()) > checkValue {
 					return NewValidationError(ErrMaxValidationFailed, fieldName)
 				}
 			}
@@ -150,83 +205,318 @@ func checkMax(fieldName string, field reflect.Value, tag string) error {
 	return nil
 }
 
+func checkRequired(fieldName string, field reflect.Value) error {
+	if field.IsZero() {
+		return NewValidationError(ErrRequiredValidationFailed, fieldName)
+	}
+	return nil
+}
+
+func checkEmail(fieldName string, field reflect.Value) error {
+	return checkString(fieldName, field, ErrEmailValidationFailed, isEmail)
+}
+
+// isEmail reports whether s is a valid e-mail address as defined for
+// <input type="email"> by the HTML standard, e.g. "user@example.com".
+func isEmail(s string) bool {
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok || local == "" || domain == "" {
+		return false
+	}
+	for i := 0; i < len(local); i++ {
+		if !isAlphanumeric(local[i]) && !strings.ContainsRune(".!#$%&'*+/=?^_`{|}~-", rune(local[i])) {
+			return false
+		}
+	}
+	for domain != "" {
+		var label string
+		label, domain, ok = strings.Cut(domain, ".")
+		if label == "" || len(label) > 63 || ok && domain == "" {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if !isAlphanumeric(label[i]) && label[i] != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isParamless reports whether validator is written without a value.
+func isParamless(validator string) bool {
+	switch validator {
+	case "required", "omitempty", "dive", "email", "enum", "immutable", "monotonic",
+		"nobidi", "noinvisible", "nocontrol", "singlescript", "filename":
+		return true
+	}
+	return false
+}
+
 func checkValidator(fieldName, tag string) (string, string, error) {
-	split := strings.Split(tag, ":")
-	validator := split[0]
-	value := split[1]
+	validator, value, ok := strings.Cut(tag, ":")
+	if ok == isParamless(validator) && validator != "safepath" {
+		return "", "", NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+	}
+	if err := checkParam(fieldName, validator, value); err != nil {
+		return "", "", err
+	}
+	return validator, value, nil
+}
+
+func checkParam(fieldName, validator, value string) error {
+	if isVariable(value) {
+		return nil
+	}
 	if validator == "len" || validator == "min" || validator == "max" {
 		if _, err := strconv.Atoi(value); err != nil {
-			return "", "", NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+			return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
 		}
 	}
 	if validator == "len" {
 		if v, _ := strconv.Atoi(value); v < 0 {
-			return "", "", NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+			return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
 		}
 	}
 	if validator == "in" {
-		checkValues := strings.Split(value, ",")
-		notEmptyCounter := 0
-		for _, s := range checkValues {
-			if s != "" {
-				notEmptyCounter++
+		if strings.Trim(value, ",") == "" {
+			return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+		}
+	}
+	if validator == "ext" {
+		for _, ext := range strings.Split(value, ",") {
+			if strings.TrimPrefix(ext, ".") == "" {
+				return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
 			}
 		}
-		if notEmptyCounter == 0 {
-			return "", "", NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+	}
+	if validator == "safepath" && value != "" {
+		if path.Clean(value) != value || !isSafePath(value, "") {
+			return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
 		}
 	}
-	return validator, value, nil
+	if validator == "transition" {
+		for _, transition := range strings.Split(value, ",") {
+			if from, to, ok := strings.Cut(transition, ">"); !ok || from == "" || to == "" {
+				return NewValidationError(ErrInvalidValidatorSyntax, fieldName)
+			}
+		}
+	}
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// pathSegment is a field name, or an index into a slice if name is empty.
+type pathSegment struct {
+	name  string
+	index int
+}
+
+// fieldPath is the stack of segments leading from the validated value to
+// the field being checked. It is only rendered to a string when validation
+// fails and is pooled, so that valid values are checked without allocating.
+type fieldPath []pathSegment
+
+var fieldPathPool = sync.Pool{
+	New: func() any {
+		return new(fieldPath)
+	},
 }
 
-func validateValue(reflectValue reflect.Value, resErrors *[]error) {
-	valueType := reflectValue.Type()
+func (p fieldPath) String() string {
+	var b strings.Builder
+	for i, segment := range p {
+		if segment.name == "" {
+			b.WriteString("[" + strconv.Itoa(segment.index) + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment.name)
+	}
+	return b.String()
+}
+
+// sibling renders the path of the field called name in the struct that
+// holds the field p points to.
+func (p fieldPath) sibling(name string) string {
+	return append(p[:len(p)-1:len(p)-1], pathSegment{name: name}).String()
+}
+
+// elem renders the path of the element at index of the slice p points to,
+// or of p itself if index is negative.
+func (p fieldPath) elem(index int) string {
+	if index < 0 {
+		return p.String()
+	}
+	return fmt.Sprintf("%s[%d]", p, index)
+}
+
+func validateValue(reflectValue reflect.Value, path *fieldPath, o *options, resErrors *Errors) {
 	if reflectValue.Kind() != reflect.Struct {
-		*resErrors = append(*resErrors, NewValidationError(ErrNotStruct, ""))
+		resErrors.fail(NewValidationError(ErrNotStruct, ""), path.String(), Rule{}, reflectValue, o)
 		return
 	}
 	for i := 0; i < reflectValue.NumField(); i++ {
-		if reflectValue.Field(i).Kind() == reflect.Struct {
-			validateValue(reflectValue.Field(i), resErrors)
+		*path = append(*path, pathSegment{name: reflectValue.Type().Field(i).Name})
+		validateField(reflectValue, i, path, o, resErrors)
+		*path = (*path)[:len(*path)-1]
+	}
+}
+
+func validateField(structValue reflect.Value, i int, path *fieldPath, o *options, resErrors *Errors) {
+	structField := structValue.Type().Field(i)
+	field := structValue.Field(i)
+	if field.Kind() == reflect.Struct {
+		validateValue(field, path, o, resErrors)
+		return
+	}
+	if (field.Kind() == reflect.Slice || field.Kind() == reflect.Array) && field.Type().Elem().Kind() == reflect.Struct {
+		for j := 0; j < field.Len(); j++ {
+			*path = append(*path, pathSegment{index: j})
+			validateValue(field.Index(j), path, o, resErrors)
+			*path = (*path)[:len(*path)-1]
+		}
+	}
+	tag, ok := structField.Tag.Lookup("validate")
+	if !structField.IsExported() {
+		if ok {
+			resErrors.fail(NewValidationError(ErrValidateForUnexportedFields, structField.Name), path.String(), Rule{}, reflect.Value{}, o)
+		}
+		return
+	}
+	if ok && field.IsZero() && startsWithOmitEmpty(structField.Name, tag, o.syntax) {
+		return
+	}
+	if u := unionOf(structValue.Type(), i); u != nil {
+		validateUnion(structValue, field, u, path, o, resErrors)
+	}
+	validateTypeRules(structField.Name, field, path, o, resErrors)
+	if ok {
+		validateRules(structField.Name, field, -1, tag, o.syntax, path, o, resErrors)
+	}
+}
+
+// validateTypeRules checks field, or each element of field if it is a
+// slice, against the rules of its type.
+func validateTypeRules(fieldName string, field reflect.Value, path *fieldPath, o *options, resErrors *Errors) {
+	if rules := typeRules(field.Type()); rules != "" {
+		validateRules(fieldName, field, -1, rules, SyntaxNative, path, o, resErrors)
+		return
+	}
+	if field.Kind() != reflect.Slice {
+		return
+	}
+	if rules := typeRules(field.Type().Elem()); rules != "" {
+		for j := 0; j < field.Len(); j++ {
+			validateRules(fieldName, field.Index(j), j, rules, SyntaxNative, path, o, resErrors)
+		}
+	}
+}
+
+// validateRules checks field against every rule in tag. index is the
+// position of field in the slice it belongs to after a dive, or -1.
+func validateRules(fieldName string, field reflect.Value, index int, tag string, syntax TagSyntax, path *fieldPath, o *options, resErrors *Errors) {
+	for tag != "" {
+		var rule Rule
+		var err error
+		rule, tag, err = nextRule(fieldName, tag, syntax)
+		if err != nil {
+			resErrors.fail(err, path.elem(index), Rule{}, field, o)
+			return
+		}
+		if syntax == SyntaxPlayground && field.Kind() == reflect.Slice && (rule.Name == "len" || rule.Name == "min" || rule.Name == "max") {
+			err = fmt.Errorf("%w: %s on a slice requires dive", ErrInvalidValidatorSyntax, rule.Name)
+			resErrors.fail(NewValidationError(err, fieldName), path.elem(index), rule, field, o)
+			return
+		}
+		if rule, err = resolveParam(fieldName, rule, o); err != nil {
+			resErrors.fail(err, path.elem(index), rule, field, o)
 			continue
 		}
-		if tag, ok := valueType.Field(i).Tag.Lookup("validate"); ok {
-			if !valueType.Field(i).IsExported() {
-				*resErrors = append(*resErrors, NewValidationError(ErrValidateForUnexportedFields, valueType.Field(i).Name))
-				continue
+		switch rule.Name {
+		case "omitempty":
+			if field.IsZero() {
+				return
 			}
-			var validator string
-			var checkValue string
-			var err error
-			if validator, checkValue, err = checkValidator(valueType.Field(i).Name, tag); err != nil {
-				*resErrors = append(*resErrors, err)
-				continue
+		case "dive":
+			if field.Kind() != reflect.Slice {
+				resErrors.fail(NewValidationError(ErrInvalidValidatorSyntax, fieldName), path.elem(index), rule, field, o)
+				return
 			}
-			switch validator {
-			case "len":
-				if err := checkLength(valueType.Field(i).Name, reflectValue.Field(i), checkValue); err != nil {
-					*resErrors = append(*resErrors, err)
-				}
-			case "in":
-				if err := checkIn(valueType.Field(i).Name, reflectValue.Field(i), checkValue); err != nil {
-					*resErrors = append(*resErrors, err)
-				}
-			case "min":
-				if err := checkMin(valueType.Field(i).Name, reflectValue.Field(i), checkValue); err != nil {
-					*resErrors = append(*resErrors, err)
-				}
-			case "max":
-				if err := checkMax(valueType.Field(i).Name, reflectValue.Field(i), checkValue); err != nil {
-					*resErrors = append(*resErrors, err)
-				}
+			for j := 0; j < field.Len(); j++ {
+				validateRules(fieldName, field.Index(j), j, tag, syntax, path, o, resErrors)
 			}
+			return
+		case "required":
+			err = checkRequired(fieldName, field)
+		case "email":
+			err = checkEmail(fieldName, field)
+		case "nobidi":
+			err = checkString(fieldName, field, ErrNoBidiValidationFailed, hasNoBidi)
+		case "noinvisible":
+			err = checkString(fieldName, field, ErrNoInvisibleValidationFailed, hasNoInvisible)
+		case "nocontrol":
+			err = checkString(fieldName, field, ErrNoControlValidationFailed, hasNoControl)
+		case "singlescript":
+			err = checkString(fieldName, field, ErrSingleScriptValidationFailed, isSingleScript)
+		case "filename":
+			err = checkString(fieldName, field, ErrFilenameValidationFailed, isFilename)
+		case "safepath":
+			err = checkString(fieldName, field, ErrSafePathValidationFailed, func(s string) bool {
+				return isSafePath(s, rule.Param)
+			})
+		case "ext":
+			err = checkString(fieldName, field, ErrExtValidationFailed, func(s string) bool {
+				return hasExt(s, rule.Param)
+			})
+		case "enum":
+			err = checkEnum(fieldName, field)
+		case "len":
+			err = checkLength(fieldName, field, rule.Param)
+		case "in":
+			err = checkIn(fieldName, field, rule.Param)
+		case "min":
+			err = checkMin(fieldName, field, rule.Param)
+		case "max":
+			err = checkMax(fieldName, field, rule.Param)
 		}
+		if err != nil {
+			resErrors.fail(err, path.elem(index), rule, field, o)
+		}
+	}
+}
 
+func Validate(v any, opts ...Option) error {
+	o := newOptions(opts)
+	value := reflect.ValueOf(v)
+	resErrors := validateErrors(value, &o)
+	if o.locateJSON && len(resErrors) > 0 && value.IsValid() {
+		resErrors.locateJSON(o.jsonSource, value.Type())
 	}
+	return resErrors.result(o.order)
 }
 
-func Validate(v any) error {
-	resErrors := make([]error, 0)
-	validateValue(reflect.ValueOf(v), &resErrors)
-	return errors.Join(resErrors...)
+// validateErrors returns the failures of v in traversal order.
+func validateErrors(v reflect.Value, o *options) Errors {
+	var resErrors Errors
+	path := fieldPathPool.Get().(*fieldPath)
+	validateValue(v, path, o, &resErrors)
+	*path = (*path)[:0]
+	fieldPathPool.Put(path)
+	return resErrors
 }
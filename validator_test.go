@@ -0,0 +1,118 @@
+package validator
+
+import "testing"
+
+type allocLen struct {
+	Name string `validate:"len:5"`
+}
+
+type allocIn struct {
+	Role  string `validate:"in:admin,user,guest"`
+	Level int    `validate:"in:1,2,3"`
+}
+
+type allocMin struct {
+	Name string `validate:"min:2"`
+	Age  int    `validate:"min:18"`
+}
+
+type allocMax struct {
+	Name string `validate:"max:10"`
+	Age  int    `validate:"max:150"`
+}
+
+type allocRequired struct {
+	Name  string `validate:"required"`
+	Count int    `validate:"required"`
+}
+
+type allocEmail struct {
+	Email string `validate:"email"`
+}
+
+type allocNested struct {
+	ID      string `validate:"len:4"`
+	Address struct {
+		Zip  string `validate:"len:5"`
+		City string `validate:"required|max:20"`
+	}
+}
+
+type allocSlice struct {
+	Tags   []string `validate:"dive|min:2|max:8"`
+	Scores []int    `validate:"required|dive|in:1,2,3"`
+	Addrs  []allocLen
+}
+
+func allocCases() []struct {
+	name string
+	v    any
+} {
+	nested := allocNested{ID: "a1b2"}
+	nested.Address.Zip = "12345"
+	nested.Address.City = "Berlin"
+	return []struct {
+		name string
+		v    any
+	}{
+		{"len", allocLen{Name: "alice"}},
+		{"in", allocIn{Role: "user", Level: 2}},
+		{"min", allocMin{Name: "al", Age: 30}},
+		{"max", allocMax{Name: "alice", Age: 30}},
+		{"required", allocRequired{Name: "alice", Count: 1}},
+		{"email", allocEmail{Email: "alice@example.com"}},
+		{"nested", nested},
+		{"slice", allocSlice{Tags: []string{"go", "rust"}, Scores: []int{1, 3}, Addrs: []allocLen{{"alice"}, {"bobby"}}}},
+	}
+}
+
+func TestValidateNoAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random in race builds")
+	}
+	for _, tt := range allocCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.v); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if allocs := testing.AllocsPerRun(100, func() { _ = Validate(tt.v) }); allocs != 0 {
+				t.Fatalf("Validate allocated %v times per run", allocs)
+			}
+		})
+	}
+}
+
+func benchmarkValidate(b *testing.B, name string) {
+	for _, tt := range allocCases() {
+		if tt.name != name {
+			continue
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := Validate(tt.v); err != nil {
+				b.Fatal(err)
+			}
+		}
+		return
+	}
+	b.Fatalf("no case %q", name)
+}
+
+func BenchmarkValidateLen(b *testing.B)      { benchmarkValidate(b, "len") }
+func BenchmarkValidateIn(b *testing.B)       { benchmarkValidate(b, "in") }
+func BenchmarkValidateMin(b *testing.B)      { benchmarkValidate(b, "min") }
+func BenchmarkValidateMax(b *testing.B)      { benchmarkValidate(b, "max") }
+func BenchmarkValidateRequired(b *testing.B) { benchmarkValidate(b, "required") }
+func BenchmarkValidateEmail(b *testing.B)    { benchmarkValidate(b, "email") }
+func BenchmarkValidateNested(b *testing.B)   { benchmarkValidate(b, "nested") }
+func BenchmarkValidateSlice(b *testing.B)    { benchmarkValidate(b, "slice") }
+
+func BenchmarkValidateInvalid(b *testing.B) {
+	v := allocMin{Name: "a", Age: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Validate(v); err == nil {
+			b.Fatal("Validate accepted an invalid value")
+		}
+	}
+}
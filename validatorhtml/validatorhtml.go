@@ -0,0 +1,195 @@
+// Package validatorhtml renders the `validate` rules of a struct field as
+// HTML form attributes, so that browser-side validation matches
+// validator.Validate.
+package validatorhtml
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+// Attrs returns the attributes of an input for the field at path, as
+// reported by ValidationError.Path or listed by validator.Describe, of
+// struct type t:
+//
+//	required   required
+//	len:n      minlength="n" maxlength="n" on strings
+//	min:n      minlength="n" on strings, min="n" on numbers
+//	max:n      maxlength="n" on strings, max="n" on numbers
+//	email      type="email"
+//	in:a,b     pattern="a|b" and list naming the element from Datalist
+//	ext:a,b    accept=".a,.b", for file inputs
+//
+// Browsers count lengths in UTF-16 code units where Validate counts bytes,
+// so the two agree on ASCII text only. Rules without an HTML equivalent,
+// rules on slices and parameters that are unresolved variables are left
+// out.
+func Attrs(t reflect.Type, path string, opts ...validator.Option) (template.HTMLAttr, error) {
+	field, err := lookupField(t, path, opts)
+	if err != nil {
+		return "", err
+	}
+	if field.Type.Kind() == reflect.Slice {
+		return "", nil
+	}
+	attrs := make([]string, 0)
+	for _, rule := range field.Rules {
+		if rule.IsVariable() {
+			continue
+		}
+		param := template.HTMLEscapeString(rule.Param)
+		switch {
+		case rule.Name == "required":
+			attrs = append(attrs, "required")
+		case rule.Name == "email":
+			attrs = append(attrs, `type="email"`)
+		case rule.Name == "len" && isString(field.Type):
+			attrs = append(attrs, `minlength="`+param+`"`, `maxlength="`+param+`"`)
+		case rule.Name == "min" && isString(field.Type):
+			attrs = append(attrs, `minlength="`+param+`"`)
+		case rule.Name == "max" && isString(field.Type):
+			attrs = append(attrs, `maxlength="`+param+`"`)
+		case rule.Name == "min" && isNumber(field.Type):
+			attrs = append(attrs, `min="`+param+`"`)
+		case rule.Name == "max" && isNumber(field.Type):
+			attrs = append(attrs, `max="`+param+`"`)
+		case rule.Name == "ext":
+			exts := strings.Split(rule.Param, ",")
+			for i, ext := range exts {
+				exts[i] = "." + strings.TrimPrefix(ext, ".")
+			}
+			attrs = append(attrs, `accept="`+template.HTMLEscapeString(strings.Join(exts, ","))+`"`)
+		case rule.Name == "in":
+			attrs = append(attrs, `pattern="`+template.HTMLEscapeString(inPattern(rule.Param))+`"`,
+				`list="`+template.HTMLEscapeString(DatalistID(path))+`"`)
+		}
+	}
+	return template.HTMLAttr(strings.Join(attrs, " ")), nil
+}
+
+// Datalist returns the <datalist> element listing the values allowed by
+// the in rule of the field at path of struct type t, or nothing if the
+// field has no such rule. Its id is DatalistID(path).
+func Datalist(t reflect.Type, path string, opts ...validator.Option) (template.HTML, error) {
+	field, err := lookupField(t, path, opts)
+	if err != nil {
+		return "", err
+	}
+	for _, rule := range field.Rules {
+		if rule.Name != "in" || rule.IsVariable() || field.Type.Kind() == reflect.Slice {
+			continue
+		}
+		var b strings.Builder
+		b.WriteString(`<datalist id="` + template.HTMLEscapeString(DatalistID(path)) + `">`)
+		for _, value := range strings.Split(rule.Param, ",") {
+			b.WriteString(`<option value="` + template.HTMLEscapeString(value) + `"></option>`)
+		}
+		b.WriteString("</datalist>")
+		return template.HTML(b.String()), nil
+	}
+	return "", nil
+}
+
+// DatalistID returns the id of the <datalist> for the field at path.
+func DatalistID(path string) string {
+	return strings.NewReplacer(".", "-", "[", "-", "]", "").Replace(path) + "-values"
+}
+
+// FuncMap returns the functions validateAttrs and validateDatalist for
+// html/template, which call Attrs and Datalist with opts. Their first
+// argument is a reflect.Type or a value, or pointer to a value, of the
+// struct type:
+//
+//	<input name="Name" {{validateAttrs . "Name"}}>
+//	{{validateDatalist . "Status"}}
+func FuncMap(opts ...validator.Option) template.FuncMap {
+	return template.FuncMap{
+		"validateAttrs": func(v any, path string) (template.HTMLAttr, error) {
+			return Attrs(structType(v), path, opts...)
+		},
+		"validateDatalist": func(v any, path string) (template.HTML, error) {
+			return Datalist(structType(v), path, opts...)
+		},
+	}
+}
+
+func structType(v any) reflect.Type {
+	if t, ok := v.(reflect.Type); ok {
+		return t
+	}
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// indexPattern matches the indexes in a path such as "Addrs[2].Zip".
+var indexPattern = regexp.MustCompile(`\[[0-9]+\]`)
+
+// lookupField returns the rules of the field at path of t. Indexes into
+// slices and arrays of structs may be given, as in "Addrs[2].Zip", or
+// left out, as in "Addrs[].Zip". A field that exists but has no rules
+// yields no rules.
+func lookupField(t reflect.Type, path string, opts []validator.Option) (validator.FieldRules, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return validator.FieldRules{}, validator.NewValidationError(validator.ErrNotStruct, "")
+	}
+	fields, err := validator.Describe(t, opts...)
+	if err != nil {
+		return validator.FieldRules{}, err
+	}
+	describedPath := indexPattern.ReplaceAllString(path, "[]")
+	for _, field := range fields {
+		if field.Path == describedPath {
+			return field, nil
+		}
+	}
+	fieldType := t
+	for _, name := range strings.Split(describedPath, ".") {
+		name, elem := strings.CutSuffix(name, "[]")
+		if fieldType.Kind() != reflect.Struct {
+			return validator.FieldRules{}, fmt.Errorf("validatorhtml: %s has no field %q", t, path)
+		}
+		structField, ok := fieldType.FieldByName(name)
+		if !ok {
+			return validator.FieldRules{}, fmt.Errorf("validatorhtml: %s has no field %q", t, path)
+		}
+		fieldType = structField.Type
+		if elem {
+			if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+				return validator.FieldRules{}, fmt.Errorf("validatorhtml: %s has no field %q", t, path)
+			}
+			fieldType = fieldType.Elem()
+		}
+	}
+	return validator.FieldRules{Path: path, Type: fieldType}, nil
+}
+
+// inPattern returns the pattern matching exactly the values of an in rule.
+func inPattern(param string) string {
+	values := strings.Split(param, ",")
+	for i, value := range values {
+		values[i] = regexp.QuoteMeta(value)
+	}
+	return strings.Join(values, "|")
+}
+
+func isString(t reflect.Type) bool {
+	return t.Kind() == reflect.String
+}
+
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
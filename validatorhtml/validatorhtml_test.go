@@ -0,0 +1,109 @@
+package validatorhtml
+
+import (
+	"html/template"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+type address struct {
+	Zip     string `validate:"len:5"`
+	Country string `validate:"in:de,fr"`
+}
+
+type signup struct {
+	Name     string   `validate:"required|min:3|max:20"`
+	Age      int      `validate:"min:18|max:130"`
+	Email    string   `validate:"required|email"`
+	Plan     string   `validate:"in:free,pro+,a<b"`
+	Currency string   `validate:"in:$USD,$EUR"`
+	Items    int      `validate:"max:$plan.max_items"`
+	Avatar   string   `validate:"ext:png,.jpg"`
+	Tags     []string `validate:"dive|len:2"`
+	Home     address
+	Addrs    []address
+	Note     string
+}
+
+func TestAttrs(t *testing.T) {
+	tests := []struct {
+		path string
+		want template.HTMLAttr
+	}{
+		{"Name", `required minlength="3" maxlength="20"`},
+		{"Age", `min="18" max="130"`},
+		{"Email", `required type="email"`},
+		{"Plan", `pattern="free|pro\+|a&lt;b" list="Plan-values"`},
+		{"Currency", `pattern="\$USD|\$EUR" list="Currency-values"`},
+		{"Items", ``},
+		{"Avatar", `accept=".png,.jpg"`},
+		{"Tags", ``},
+		{"Home.Zip", `minlength="5" maxlength="5"`},
+		{"Addrs[].Zip", `minlength="5" maxlength="5"`},
+		{"Addrs[3].Country", `pattern="de|fr" list="Addrs-3-Country-values"`},
+		{"Note", ``},
+		{"Home", ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Attrs(reflect.TypeFor[signup](), tt.path)
+			if err != nil || got != tt.want {
+				t.Fatalf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttrsParams(t *testing.T) {
+	got, err := Attrs(reflect.TypeFor[signup](), "Items", validator.WithParams(validator.Params{"plan.max_items": "5"}))
+	if err != nil || got != `max="5"` {
+		t.Fatalf("got %q, %v", got, err)
+	}
+}
+
+func TestAttrsErrors(t *testing.T) {
+	for _, path := range []string{"Missing", "Name.First", "Home.Missing", "Home[].Zip", "Addrs[x].Zip"} {
+		if _, err := Attrs(reflect.TypeFor[signup](), path); err == nil || !strings.Contains(err.Error(), "has no field") {
+			t.Errorf("%s: got %v", path, err)
+		}
+	}
+	if _, err := Attrs(reflect.TypeFor[int](), "Name"); err == nil {
+		t.Error("got nil error for a non-struct")
+	}
+	type bad struct {
+		Name string `validate:"min"`
+	}
+	if _, err := Attrs(reflect.TypeFor[bad](), "Name"); err == nil {
+		t.Error("got nil error for an invalid tag")
+	}
+}
+
+func TestDatalist(t *testing.T) {
+	got, err := Datalist(reflect.TypeFor[signup](), "Plan")
+	want := template.HTML(`<datalist id="Plan-values"><option value="free"></option><option value="pro+"></option><option value="a&lt;b"></option></datalist>`)
+	if err != nil || got != want {
+		t.Fatalf("got %q, %v; want %q", got, err, want)
+	}
+	for _, path := range []string{"Name", "Items", "Tags"} {
+		if got, err := Datalist(reflect.TypeFor[signup](), path); err != nil || got != "" {
+			t.Errorf("%s: got %q, %v", path, got, err)
+		}
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("form").Funcs(FuncMap()).Parse(
+		`<input name="Name" {{validateAttrs . "Name"}}>{{validateDatalist . "Home.Country"}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, &signup{}); err != nil {
+		t.Fatal(err)
+	}
+	want := `<input name="Name" required minlength="3" maxlength="20">` +
+		`<datalist id="Home-Country-values"><option value="de"></option><option value="fr"></option></datalist>`
+	if b.String() != want {
+		t.Fatalf("got %s, want %s", b.String(), want)
+	}
+}
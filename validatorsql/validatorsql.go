@@ -0,0 +1,332 @@
+// Package validatorsql generates SQL table definitions whose CHECK
+// constraints enforce the `validate` rules of a struct type, so that the
+// database holds the same invariants as validator.Validate.
+package validatorsql
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+// Dialect selects the SQL flavour of the generated definitions.
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	SQLite
+)
+
+// Table is the definition generated for a struct type.
+type Table struct {
+	Name    string
+	Columns []Column
+	// Unsupported lists the rules, and the fields, that have no SQL
+	// equivalent and are therefore not enforced by the table.
+	Unsupported []Unsupported
+}
+
+// Column is the definition generated for one struct field.
+type Column struct {
+	Name string
+	// Path is the path of the field, as reported by ValidationError.Path.
+	Path    string
+	Type    string
+	NotNull bool
+	// Check is the condition of the column's CHECK constraint, or empty.
+	Check string
+}
+
+// Unsupported is a rule, or a whole field if Rule is zero, that Generate
+// could not translate.
+type Unsupported struct {
+	Path   string
+	Rule   validator.Rule
+	Reason string
+}
+
+func (u Unsupported) String() string {
+	if u.Rule.Name == "" {
+		return fmt.Sprintf("%s: %s", u.Path, u.Reason)
+	}
+	if u.Rule.Param == "" {
+		return fmt.Sprintf("%s: %s: %s", u.Path, u.Rule.Name, u.Reason)
+	}
+	return fmt.Sprintf("%s: %s:%s: %s", u.Path, u.Rule.Name, u.Rule.Param, u.Reason)
+}
+
+// Generate returns the definition of table name holding values of struct
+// type t in dialect. Every field becomes a column named by its `db` tag,
+// or its name in snake case; a `db:"-"` tag leaves the field out. Fields
+// of nested structs are prefixed with the name of the struct field,
+// except for embedded structs.
+//
+// The rules of each field, see validator.Describe, become constraints:
+// required becomes NOT NULL and, for booleans, strings and numbers, a
+// check that the value is not zero; len, min, max and in become a CHECK
+// constraint, keeping the tightest of several min or max rules. A leading
+// omitempty lets zero values bypass the check. Lengths are counted in
+// bytes, as Validate does. Rules on pointers and slices, and email, enum,
+// union and the ValidateUpdate rules, have no equivalent and are listed in
+// Table.Unsupported.
+func Generate(name string, t reflect.Type, dialect Dialect, opts ...validator.Option) (Table, error) {
+	fields, err := validator.Describe(t, opts...)
+	if err != nil {
+		return Table{}, err
+	}
+	g := generator{dialect: dialect, fields: fields, table: Table{Name: name}}
+	g.structColumns(t, "", "", nil)
+	for _, field := range fields {
+		if slices.Contains(field.Index, -1) {
+			for _, rule := range field.Rules {
+				g.unsupported(field.Path, rule, "not checked in slices")
+			}
+		}
+	}
+	return g.table, nil
+}
+
+type generator struct {
+	dialect Dialect
+	fields  []validator.FieldRules
+	table   Table
+}
+
+func (g *generator) structColumns(t reflect.Type, prefix, path string, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		column, ok := field.Tag.Lookup("db")
+		if column == "-" || !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		if !ok {
+			column = snakeCase(field.Name)
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeFor[time.Time]() {
+			if field.Anonymous && !ok {
+				g.structColumns(field.Type, prefix, fieldPath, fieldIndex)
+			} else {
+				g.structColumns(field.Type, prefix+column+"_", fieldPath, fieldIndex)
+			}
+			continue
+		}
+		g.column(prefix+column, fieldPath, field.Type, g.rules(fieldIndex))
+	}
+}
+
+func (g *generator) rules(index []int) []validator.Rule {
+	for _, field := range g.fields {
+		if slices.Equal(field.Index, index) {
+			return field.Rules
+		}
+	}
+	return nil
+}
+
+func (g *generator) column(name, path string, t reflect.Type, rules []validator.Rule) {
+	nullable := t.Kind() == reflect.Pointer
+	if nullable {
+		t = t.Elem()
+	}
+	sqlType, ok := g.sqlType(t)
+	if !ok {
+		g.unsupported(path, validator.Rule{}, fmt.Sprintf("no column type for %s", t))
+		for _, rule := range rules {
+			g.unsupported(path, rule, fmt.Sprintf("no column for %s", t))
+		}
+		return
+	}
+	column := Column{Name: name, Path: path, Type: sqlType}
+	quoted := quoteIdent(name)
+	value := quoted
+	if t.Kind() == reflect.String {
+		value = g.length(quoted)
+	}
+	conditions := make([]string, 0)
+	omitEmpty := false
+	low, high := math.MinInt, math.MaxInt
+	for i, rule := range rules {
+		if nullable && rule.Name != "required" && rule.Name != "omitempty" {
+			g.unsupported(path, rule, "not checked on pointers")
+			continue
+		}
+		if rule.IsVariable() {
+			g.unsupported(path, rule, "parameter is a variable")
+			continue
+		}
+		switch {
+		case rule.Name == "omitempty" && i == 0:
+			omitEmpty = true
+		case rule.Name == "required":
+			column.NotNull = true
+			if zero, ok := g.zeroLiteral(t); ok && !nullable {
+				conditions = append(conditions, quoted+" <> "+zero)
+			}
+		case rule.Name == "len" && t.Kind() == reflect.String:
+			conditions = append(conditions, value+" = "+rule.Param)
+		case rule.Name == "min" && isNumberOrString(t):
+			n, _ := strconv.Atoi(rule.Param)
+			low = max(low, n)
+		case rule.Name == "max" && isNumberOrString(t):
+			n, _ := strconv.Atoi(rule.Param)
+			high = min(high, n)
+		case rule.Name == "in" && isNumberOrString(t):
+			conditions = append(conditions, quoted+" IN ("+inList(t, rule.Param)+")")
+		default:
+			g.unsupported(path, rule, "no SQL equivalent")
+		}
+	}
+	switch {
+	case low != math.MinInt && high != math.MaxInt:
+		conditions = append(conditions, value+" BETWEEN "+strconv.Itoa(low)+" AND "+strconv.Itoa(high))
+	case low != math.MinInt:
+		conditions = append(conditions, value+" >= "+strconv.Itoa(low))
+	case high != math.MaxInt:
+		conditions = append(conditions, value+" <= "+strconv.Itoa(high))
+	}
+	column.Check = strings.Join(conditions, " AND ")
+	if zero, ok := g.zeroLiteral(t); omitEmpty && ok && len(conditions) > 0 {
+		if len(conditions) > 1 {
+			column.Check = "(" + column.Check + ")"
+		}
+		column.Check = quoted + " = " + zero + " OR " + column.Check
+	}
+	g.table.Columns = append(g.table.Columns, column)
+}
+
+func (g *generator) unsupported(path string, rule validator.Rule, reason string) {
+	g.table.Unsupported = append(g.table.Unsupported, Unsupported{Path: path, Rule: rule, Reason: reason})
+}
+
+func (g *generator) sqlType(t reflect.Type) (string, bool) {
+	if t == reflect.TypeFor[time.Time]() {
+		return g.pick("timestamptz", "TEXT"), true
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return g.pick("text", "TEXT"), true
+	case reflect.Bool:
+		return g.pick("boolean", "INTEGER"), true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return g.pick("smallint", "INTEGER"), true
+	case reflect.Int32, reflect.Uint16:
+		return g.pick("integer", "INTEGER"), true
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return g.pick("bigint", "INTEGER"), true
+	case reflect.Float32:
+		return g.pick("real", "REAL"), true
+	case reflect.Float64:
+		return g.pick("double precision", "REAL"), true
+	}
+	return "", false
+}
+
+func (g *generator) pick(postgres, sqlite string) string {
+	if g.dialect == SQLite {
+		return sqlite
+	}
+	return postgres
+}
+
+// length returns the expression for the length in bytes of column.
+func (g *generator) length(column string) string {
+	if g.dialect == SQLite {
+		return "length(CAST(" + column + " AS BLOB))"
+	}
+	return "octet_length(" + column + ")"
+}
+
+// zeroLiteral returns the SQL literal of the zero value of booleans,
+// strings and numbers.
+func (g *generator) zeroLiteral(t reflect.Type) (string, bool) {
+	switch {
+	case t.Kind() == reflect.Bool:
+		return g.pick("false", "0"), true
+	case t.Kind() == reflect.String:
+		return "''", true
+	case isNumberOrString(t), t.Kind() == reflect.Float32, t.Kind() == reflect.Float64:
+		return "0", true
+	}
+	return "", false
+}
+
+// String returns the CREATE TABLE statement for t.
+func (t Table) String() string {
+	var b strings.Builder
+	b.WriteString("CREATE TABLE " + quoteIdent(t.Name) + " (")
+	for i, column := range t.Columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n  " + column.String())
+	}
+	b.WriteString("\n);\n")
+	return b.String()
+}
+
+// String returns the definition of c as written in CREATE TABLE.
+func (c Column) String() string {
+	res := quoteIdent(c.Name) + " " + c.Type
+	if c.NotNull {
+		res += " NOT NULL"
+	}
+	if c.Check != "" {
+		res += " CHECK (" + c.Check + ")"
+	}
+	return res
+}
+
+func isNumberOrString(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func inList(t reflect.Type, param string) string {
+	values := strings.Split(param, ",")
+	for i, value := range values {
+		if t.Kind() == reflect.String {
+			values[i] = quoteString(value)
+		} else if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			values[i] = quoteString(value)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// snakeCase converts a Go identifier such as "UserID" or "HTTPServer" to
+// "user_id" or "http_server".
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,167 @@
+package validatorsql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+type audit struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type address struct {
+	Zip string `validate:"len:5"`
+}
+
+type user struct {
+	audit
+	ID       int64   `db:"id" validate:"required"`
+	Name     string  `validate:"min:3|max:20"`
+	Nick     string  `validate:"omitempty|min:2|in:al,bo"`
+	Role     string  `validate:"in:admin,o'brien"`
+	Level    int     `validate:"in:1,2"`
+	Score    float64 `validate:"min:0"`
+	Email    string  `validate:"email"`
+	Bio      *string `validate:"required|max:100"`
+	Items    int     `validate:"max:$plan.max_items"`
+	Currency string  `validate:"in:$USD,$EUR"`
+	Home     address
+	Addrs    []address
+	Tags     []string `validate:"dive|len:2"`
+	Secret   string   `db:"-" validate:"required"`
+	HTTPPort uint16
+}
+
+func TestGenerate(t *testing.T) {
+	table, err := Generate("users", reflect.TypeFor[user](), Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `CREATE TABLE "users" (
+  "created_at" timestamptz,
+  "id" bigint NOT NULL CHECK ("id" <> 0),
+  "name" text CHECK (octet_length("name") BETWEEN 3 AND 20),
+  "nick" text CHECK ("nick" = '' OR ("nick" IN ('al', 'bo') AND octet_length("nick") >= 2)),
+  "role" text CHECK ("role" IN ('admin', 'o''brien')),
+  "level" bigint CHECK ("level" IN (1, 2)),
+  "score" double precision,
+  "email" text,
+  "bio" text NOT NULL,
+  "items" bigint,
+  "currency" text CHECK ("currency" IN ('$USD', '$EUR')),
+  "home_zip" text CHECK (octet_length("home_zip") = 5),
+  "http_port" integer
+);
+`
+	if got := table.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	wantUnsupported := []string{
+		"Score: min:0: no SQL equivalent",
+		"Email: email: no SQL equivalent",
+		"Bio: max:100: not checked on pointers",
+		"Items: max:$plan.max_items: parameter is a variable",
+		"Addrs: no column type for []validatorsql.address",
+		"Tags: no column type for []string",
+		"Tags: dive: no column for []string",
+		"Tags: len:2: no column for []string",
+		"Addrs[].Zip: len:5: not checked in slices",
+	}
+	if len(table.Unsupported) != len(wantUnsupported) {
+		t.Fatalf("got unsupported %q, want %q", table.Unsupported, wantUnsupported)
+	}
+	for i, u := range table.Unsupported {
+		if u.String() != wantUnsupported[i] {
+			t.Errorf("unsupported %d: got %q, want %q", i, u, wantUnsupported[i])
+		}
+	}
+}
+
+func TestGenerateSQLite(t *testing.T) {
+	type item struct {
+		Code  string `validate:"len:4"`
+		Price int32  `validate:"min:1"`
+		Sale  bool
+	}
+	table, err := Generate("items", reflect.TypeFor[item](), SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `CREATE TABLE "items" (
+  "code" TEXT CHECK (length(CAST("code" AS BLOB)) = 4),
+  "price" INTEGER CHECK ("price" >= 1),
+  "sale" INTEGER
+);
+`
+	if got := table.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateRequiredAndBounds(t *testing.T) {
+	type item struct {
+		Active bool `validate:"required"`
+		Qty    int  `validate:"min:1|max:10|min:3|max:5"`
+	}
+	tests := map[Dialect]string{
+		Postgres: `CREATE TABLE "items" (
+  "active" boolean NOT NULL CHECK ("active" <> false),
+  "qty" bigint CHECK ("qty" BETWEEN 3 AND 5)
+);
+`,
+		SQLite: `CREATE TABLE "items" (
+  "active" INTEGER NOT NULL CHECK ("active" <> 0),
+  "qty" INTEGER CHECK ("qty" BETWEEN 3 AND 5)
+);
+`,
+	}
+	for dialect, want := range tests {
+		table, err := Generate("items", reflect.TypeFor[item](), dialect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := table.String(); got != want {
+			t.Errorf("got:\n%s\nwant:\n%s", got, want)
+		}
+	}
+}
+
+func TestGenerateParams(t *testing.T) {
+	type item struct {
+		Qty int `validate:"max:$max_qty"`
+	}
+	table, err := Generate("items", reflect.TypeFor[item](), Postgres, validator.WithParams(validator.Params{"max_qty": "9"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Columns) != 1 || table.Columns[0].Check != `"qty" <= 9` || len(table.Unsupported) != 0 {
+		t.Fatalf("got %+v", table)
+	}
+}
+
+func TestGenerateInvalidTag(t *testing.T) {
+	type item struct {
+		Qty int `validate:"max"`
+	}
+	if _, err := Generate("items", reflect.TypeFor[item](), Postgres); err == nil {
+		t.Fatal("got nil error")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":       "name",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"createdAt":  "created_at",
+		"A":          "a",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package validatortest
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+const (
+	maxFuzzLen   = 16
+	maxFuzzDepth = 4
+)
+
+// FuzzValidate runs Validate on values of type T populated from fuzz input
+// and fails if it panics or returns an error that does not unwrap to
+// validator.ValidationError. Seed inputs can be added to f beforehand.
+//
+//	func FuzzUser(f *testing.F) {
+//		validatortest.FuzzValidate[User](f)
+//	}
+func FuzzValidate[T any](f *testing.F) {
+	f.Helper()
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v T
+		Fill(data, &v)
+		CheckValidate(t, v)
+	})
+}
+
+// CheckValidate fails the test if Validate panics on v or returns an error
+// that does not unwrap to validator.ValidationError.
+func CheckValidate(t testing.TB, v any) {
+	t.Helper()
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("Validate panicked on %#v: %v", v, r)
+			}
+		}()
+		err = validator.Validate(v)
+	}()
+	if err := checkUnwrapsToValidationError(err); err != nil {
+		t.Fatalf("Validate on %#v: %s", v, err)
+	}
+}
+
+func checkUnwrapsToValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range multi.Unwrap() {
+			if err := checkUnwrapsToValidationError(err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var validationErr *validator.ValidationError
+	if !errors.As(err, &validationErr) {
+		return fmt.Errorf("error %q (%T) does not unwrap to ValidationError", err, err)
+	}
+	return nil
+}
+
+// Fill populates the value ptr points to from data, deterministically:
+// strings, numbers, booleans, slices, arrays, nested structs and pointers
+// are consumed from the front of data, and everything is left zero once it
+// runs out. Unexported fields are skipped.
+func Fill(data []byte, ptr any) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		panic("validatortest: Fill requires a non-nil pointer")
+	}
+	c := &consumer{data: data}
+	c.fill(v.Elem(), 0)
+}
+
+type consumer struct {
+	data []byte
+}
+
+func (c *consumer) bytes(n int) []byte {
+	n = min(n, len(c.data))
+	res := c.data[:n]
+	c.data = c.data[n:]
+	return res
+}
+
+func (c *consumer) uint64() uint64 {
+	var buf [8]byte
+	copy(buf[:], c.bytes(8))
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+func (c *consumer) byte() byte {
+	if b := c.bytes(1); len(b) == 1 {
+		return b[0]
+	}
+	return 0
+}
+
+func (c *consumer) fill(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.fill(v.Field(i), depth)
+			}
+		}
+	case reflect.Pointer:
+		if depth >= maxFuzzDepth || c.byte()%2 == 0 {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		c.fill(v.Elem(), depth+1)
+	case reflect.Slice:
+		if depth >= maxFuzzDepth {
+			return
+		}
+		n := int(c.byte()) % maxFuzzLen
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			c.fill(v.Index(i), depth+1)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.fill(v.Index(i), depth+1)
+		}
+	case reflect.String:
+		v.SetString(string(c.bytes(int(c.byte()) % maxFuzzLen)))
+	case reflect.Bool:
+		v.SetBool(c.byte()%2 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(c.uint64()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(c.uint64())
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(math.Float64frombits(c.uint64()))
+	}
+}
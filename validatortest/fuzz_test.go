@@ -0,0 +1,82 @@
+package validatortest
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fuzzAddress struct {
+	Zip  string `validate:"len:5"`
+	City string `validate:"min:2|max:10"`
+}
+
+type fuzzProfile struct {
+	Name    string   `validate:"required|min:3|max:10"`
+	Email   string   `validate:"omitempty|email"`
+	Role    string   `validate:"in:admin,user"`
+	Age     int      `validate:"min:0|max:150"`
+	Level   int64    `validate:"in:1,2,3"`
+	Tags    []string `validate:"dive|len:2"`
+	Scores  []int    `validate:"dive|min:0"`
+	Home    fuzzAddress
+	Addrs   []fuzzAddress
+	Manager *fuzzProfile
+	Nick    *string
+	Display string `validate:"nobidi|noinvisible|singlescript"`
+	File    string `validate:"filename|ext:png,jpg"`
+	Key     string `validate:"safepath:uploads"`
+}
+
+func FuzzValidateProfile(f *testing.F) {
+	FuzzValidate[fuzzProfile](f)
+}
+
+type filled struct {
+	S      string
+	N      int
+	B      bool
+	L      []uint16
+	P      *int8
+	In     struct{ X string }
+	hidden string
+	Rest   []string
+}
+
+func TestFill(t *testing.T) {
+	data := []byte{
+		3, 'a', 'b', 'c', // S
+		42, 0, 0, 0, 0, 0, 0, 0, // N
+		1,                      // B
+		2,                      // len(L)
+		5, 0, 0, 0, 0, 0, 0, 0, // L[0]
+		7, 0, 0, 0, 0, 0, 0, 0, // L[1]
+		1,                                              // P is set
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // *P
+		1, 'z', // In.X
+	}
+	var got filled
+	Fill(data, &got)
+	p := int8(-1)
+	want := filled{S: "abc", N: 42, B: true, L: []uint16{5, 7}, P: &p, In: struct{ X string }{"z"}, Rest: []string{}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestFillShortData(t *testing.T) {
+	var got filled
+	Fill([]byte{5, 'a'}, &got)
+	// Slices are made with the length read from the exhausted data, 0.
+	if want := (filled{S: "a", L: []uint16{}, Rest: []string{}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFillPanicsWithoutPointer(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Fill did not panic on a non-pointer")
+		}
+	}()
+	Fill(nil, filled{})
+}
@@ -0,0 +1,273 @@
+package validatortest
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+const (
+	maxGeneratedLen   = 8
+	maxGeneratedInt   = 1000
+	generatedAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// generatedEmailDomain is appended to a random local part to satisfy email.
+	generatedEmailDomain = "@example.com"
+)
+
+// NewRand returns a deterministic source of randomness for seed, suitable
+// for Generate and GenerateInvalid.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// Generate returns a random value of struct type T that satisfies every
+// `validate` rule declared on it.
+func Generate[T any](r *rand.Rand, opts ...validator.Option) (T, error) {
+	var res T
+	v, err := GenerateValue(r, reflect.TypeOf(res), opts...)
+	if err != nil {
+		return res, err
+	}
+	return v.Interface().(T), nil
+}
+
+// GenerateInvalid returns random values of struct type T that each violate
+// exactly one rule by the smallest possible margin. It is Mutations applied
+// to a value returned by Generate.
+func GenerateInvalid[T any](r *rand.Rand, opts ...validator.Option) ([]Mutation, error) {
+	valid, err := Generate[T](r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Mutations(valid, opts...)
+}
+
+// GenerateValue is the reflect counterpart of Generate. Exported fields
+// without rules are filled with arbitrary values; pointers, maps and
+// other kinds the validator does not inspect are left zero. The elements
+// of slices and arrays of structs are generated with GenerateValue.
+func GenerateValue(r *rand.Rand, t reflect.Type, opts ...validator.Option) (reflect.Value, error) {
+	fields, err := validator.Describe(t, opts...)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	res := reflect.New(t).Elem()
+	if err := fillRandom(r, res, opts); err != nil {
+		return reflect.Value{}, err
+	}
+	for _, field := range fields {
+		if slices.Contains(field.Index, -1) {
+			// The fields of structs held in slices were generated with
+			// their element.
+			continue
+		}
+		if err := generateField(r, res.FieldByIndex(field.Index), field.Rules, opts); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return res, nil
+}
+
+func fillRandom(r *rand.Rand, v reflect.Value, opts []validator.Option) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				if err := fillRandom(r, v.Field(i), opts); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice:
+		n := r.IntN(maxGeneratedLen / 2)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			if err := fillElem(r, v.Index(i), opts); err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			break
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := fillElem(r, v.Index(i), opts); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		v.SetString(randomString(r, 0, maxGeneratedLen))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(randomInt(r, -maxGeneratedInt, maxGeneratedInt)))
+	case reflect.Bool:
+		v.SetBool(r.IntN(2) == 1)
+	}
+	return nil
+}
+
+// fillElem fills v, an element of a slice or array, generating it with
+// GenerateValue if it is a struct.
+func fillElem(r *rand.Rand, v reflect.Value, opts []validator.Option) error {
+	if v.Kind() != reflect.Struct {
+		return fillRandom(r, v, opts)
+	}
+	elem, err := GenerateValue(r, v.Type(), opts...)
+	if err != nil {
+		return err
+	}
+	v.Set(elem)
+	return nil
+}
+
+func generateField(r *rand.Rand, field reflect.Value, rules []validator.Rule, opts []validator.Option) error {
+	if field.Kind() != reflect.Slice {
+		generateScalar(r, field, rules)
+		return nil
+	}
+	elemRules, minLen := sliceRules(rules)
+	n := minLen + r.IntN(maxGeneratedLen/2)
+	field.Set(reflect.MakeSlice(field.Type(), n, n))
+	for i := 0; i < n; i++ {
+		if field.Type().Elem().Kind() == reflect.Struct {
+			if err := fillElem(r, field.Index(i), opts); err != nil {
+				return err
+			}
+			continue
+		}
+		generateScalar(r, field.Index(i), elemRules)
+	}
+	return nil
+}
+
+// sliceRules splits the rules of a slice field into the ones that apply
+// to its elements and the minimum number of elements it must have.
+func sliceRules(rules []validator.Rule) ([]validator.Rule, int) {
+	minLen := 0
+	elemRules := make([]validator.Rule, 0, len(rules))
+	for i, rule := range rules {
+		switch rule.Name {
+		case "dive":
+			return rules[i+1:], minLen
+		case "required":
+			minLen = 1
+		case "omitempty":
+		default:
+			elemRules = append(elemRules, rule)
+		}
+	}
+	return elemRules, minLen
+}
+
+// generateScalar sets v to a random value satisfying rules. The rules are
+// narrowed to a single range and an optional set of allowed values, with
+// required taken as a lower bound of 1, and strings are given the domain
+// or extension that email and ext require; if the rules cannot all hold at
+// once v is left as is.
+func generateScalar(r *rand.Rand, v reflect.Value, rules []validator.Rule) {
+	low, high := math.MinInt, math.MaxInt
+	var allowed []string
+	// prefix and suffix surround the random part of a string, which must
+	// not be empty if nonEmpty is set.
+	var prefix, suffix string
+	nonEmpty := false
+	for _, rule := range rules {
+		n, _ := strconv.Atoi(rule.Param)
+		switch rule.Name {
+		case "len":
+			low, high = max(low, n), min(high, n)
+		case "min":
+			low = max(low, n)
+		case "max":
+			high = min(high, n)
+		case "required":
+			low = max(low, 1)
+		case "email":
+			suffix, nonEmpty = generatedEmailDomain, true
+		case "ext":
+			ext, _, _ := strings.Cut(rule.Param, ",")
+			suffix, nonEmpty = "."+strings.TrimPrefix(ext, "."), true
+		case "safepath":
+			if rule.Param != "" {
+				prefix = rule.Param + "/"
+			}
+			nonEmpty = true
+		case "filename":
+			nonEmpty = true
+		case "in":
+			allowed = strings.Split(rule.Param, ",")
+		}
+	}
+	if allowed != nil {
+		candidates := allowed[:0:0]
+		for _, s := range allowed {
+			if isInRange(v.Kind(), s, low, high) {
+				candidates = append(candidates, s)
+			}
+		}
+		if len(candidates) == 0 {
+			return
+		}
+		s := candidates[r.IntN(len(candidates))]
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, _ := strconv.Atoi(s)
+			v.SetInt(int64(n))
+		}
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		affixLen := len(prefix) + len(suffix)
+		low = max(low, 0) - affixLen
+		if nonEmpty {
+			low = max(low, 1)
+		}
+		low = max(low, 0)
+		high = min(high-affixLen, max(low, maxGeneratedLen))
+		if low <= high {
+			v.SetString(prefix + randomString(r, low, high) + suffix)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if low == math.MinInt {
+			low = min(high, 0) - maxGeneratedInt
+		}
+		if high == math.MaxInt {
+			high = max(low, 0) + maxGeneratedInt
+		}
+		if low <= high {
+			v.SetInt(int64(randomInt(r, low, high)))
+		}
+	}
+}
+
+func isInRange(kind reflect.Kind, s string, low, high int) bool {
+	n := len(s)
+	if kind != reflect.String {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil {
+			return false
+		}
+	}
+	return low <= n && n <= high
+}
+
+// randomString returns a random alphanumeric string of length in [low, high].
+func randomString(r *rand.Rand, low, high int) string {
+	b := make([]byte, randomInt(r, low, high))
+	for i := range b {
+		b[i] = generatedAlphabet[r.IntN(len(generatedAlphabet))]
+	}
+	return string(b)
+}
+
+// randomInt returns a random integer in [low, high].
+func randomInt(r *rand.Rand, low, high int) int {
+	return low + int(r.Uint64N(uint64(high-low)+1))
+}
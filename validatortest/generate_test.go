@@ -0,0 +1,85 @@
+package validatortest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+type generatedAddress struct {
+	Zip   string `validate:"len:5"`
+	City  string `validate:"min:2|max:20"`
+	Lines []line
+}
+
+type line struct {
+	Text string `validate:"required|max:8"`
+}
+
+type generated struct {
+	Name     string   `validate:"required|min:3|max:10"`
+	Email    string   `validate:"email"`
+	Status   string   `validate:"in:draft,review,published"`
+	Age      int      `validate:"min:18|max:150"`
+	Level    int      `validate:"in:1,2,3"`
+	Nick     string   `validate:"omitempty|len:4"`
+	Tags     []string `validate:"required|dive|min:1|max:5"`
+	Scores   []int    `validate:"dive|min:0|max:100"`
+	Home     generatedAddress
+	Addrs    []generatedAddress
+	Required []generatedAddress `validate:"required"`
+	Fixed    [2]generatedAddress
+	File     string `validate:"filename|ext:png"`
+	Key      string `validate:"safepath:uploads"`
+	Free     string
+}
+
+func TestGenerateIsValid(t *testing.T) {
+	for seed := uint64(0); seed < 500; seed++ {
+		v, err := Generate[generated](NewRand(seed))
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if err := validator.Validate(v); err != nil {
+			t.Fatalf("seed %d: generated value is invalid:\n%v\n%+v", seed, err, v)
+		}
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	a, err := Generate[generated](NewRand(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Generate[generated](NewRand(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same seed gave different values:\n%+v\n%+v", a, b)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	for seed := uint64(0); seed < 50; seed++ {
+		mutations, err := GenerateInvalid[generated](NewRand(seed))
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if len(mutations) == 0 {
+			t.Fatalf("seed %d: no mutations", seed)
+		}
+		for _, m := range mutations {
+			if findFieldError(validator.Validate(m.Value), m.Path, m.Rule) == nil {
+				t.Fatalf("seed %d: %s was not rejected at %s by %s", seed, m.Name, m.Path, m.Rule)
+			}
+		}
+	}
+}
+
+func TestGenerateValueNotStruct(t *testing.T) {
+	if _, err := GenerateValue(NewRand(1), reflect.TypeFor[int]()); err == nil {
+		t.Fatal("GenerateValue accepted a non-struct type")
+	}
+}
@@ -0,0 +1,282 @@
+// Package validatortest provides helpers for asserting the outcome of
+// validator.Validate in tests.
+package validatortest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+// AssertValid fails the test if v does not pass validation.
+func AssertValid(t testing.TB, v any, opts ...validator.Option) {
+	t.Helper()
+	if err := validator.Validate(v, opts...); err != nil {
+		t.Fatalf("expected %T to be valid, got:\n%s", v, err)
+	}
+}
+
+// AssertFieldError fails the test unless err contains a ValidationError for
+// path produced by rule. An empty rule matches any rule.
+func AssertFieldError(t testing.TB, err error, path, rule string) {
+	t.Helper()
+	if findFieldError(err, path, rule) == nil {
+		t.Fatalf("expected %s error for %q, got:\n%v", ruleName(rule), path, err)
+	}
+}
+
+// AssertNoFieldError fails the test if err contains a ValidationError for
+// path produced by rule. An empty rule matches any rule.
+func AssertNoFieldError(t testing.TB, err error, path, rule string) {
+	t.Helper()
+	if fieldErr := findFieldError(err, path, rule); fieldErr != nil {
+		t.Fatalf("unexpected %s error for %q: %s", ruleName(rule), path, fieldErr)
+	}
+}
+
+// FieldErrors returns every ValidationError contained in err.
+func FieldErrors(err error) []*validator.ValidationError {
+	res := make([]*validator.ValidationError, 0)
+	collectFieldErrors(err, &res)
+	return res
+}
+
+func collectFieldErrors(err error, res *[]*validator.ValidationError) {
+	switch e := err.(type) {
+	case nil:
+	case *validator.ValidationError:
+		*res = append(*res, e)
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			collectFieldErrors(err, res)
+		}
+	default:
+		collectFieldErrors(errors.Unwrap(err), res)
+	}
+}
+
+func findFieldError(err error, path, rule string) *validator.ValidationError {
+	for _, fieldErr := range FieldErrors(err) {
+		if fieldErr.Path() == path && (rule == "" || fieldErr.Rule() == rule) {
+			return fieldErr
+		}
+	}
+	return nil
+}
+
+func ruleName(rule string) string {
+	if rule == "" {
+		return "any"
+	}
+	return strconv.Quote(rule)
+}
+
+// Mutation is a copy of a valid fixture with one field set to a value its
+// rule must reject.
+type Mutation struct {
+	// Name describes the mutation and is suitable for t.Run.
+	Name  string
+	Path  string
+	Rule  string
+	Value any
+}
+
+// Mutations returns, for every tagged field of the valid struct fixture,
+// copies of it with the field set just outside the bounds of its rule.
+// Rules after dive are violated by appending an element to the slice, and
+// the fields of structs held in slices by changing the first element.
+func Mutations(valid any, opts ...validator.Option) ([]Mutation, error) {
+	validValue := reflect.ValueOf(valid)
+	fields, err := validator.Describe(validValue.Type(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Mutation, 0)
+	for _, field := range fields {
+		fieldValue, ok := fieldByIndex(validValue, field.Index, false)
+		if !ok {
+			continue
+		}
+		fieldPath := strings.ReplaceAll(field.Path, "[]", "[0]")
+		dived, omitEmpty := false, false
+		for _, rule := range field.Rules {
+			switch rule.Name {
+			case "dive":
+				dived, omitEmpty = true, false
+				continue
+			case "omitempty":
+				omitEmpty = true
+				continue
+			}
+			path := fieldPath
+			if dived {
+				path = fmt.Sprintf("%s[%d]", path, fieldValue.Len())
+			}
+			for _, bad := range boundaryValues(fieldValue, rule, dived, omitEmpty) {
+				mutated := reflect.New(validValue.Type()).Elem()
+				mutated.Set(validValue)
+				mutatedField, _ := fieldByIndex(mutated, field.Index, true)
+				mutatedField.Set(bad)
+				res = append(res, Mutation{
+					Name:  fmt.Sprintf("%s %s:%s=%v", field.Path, rule.Name, rule.Param, bad),
+					Path:  path,
+					Rule:  rule.Name,
+					Value: mutated.Interface(),
+				})
+			}
+		}
+	}
+	return res, nil
+}
+
+// fieldByIndex returns the field of v at index, as listed by
+// validator.Describe, taking the first element of a slice or array where
+// index holds -1. It reports false if one of them is empty. With
+// copySlices, the slices on the way are replaced by copies first, so that
+// setting the field leaves the value v was copied from intact.
+func fieldByIndex(v reflect.Value, index []int, copySlices bool) (reflect.Value, bool) {
+	for _, i := range index {
+		if i >= 0 {
+			v = v.Field(i)
+			continue
+		}
+		if v.Len() == 0 {
+			return reflect.Value{}, false
+		}
+		if copySlices && v.Kind() == reflect.Slice {
+			c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(c, v)
+			v.Set(c)
+		}
+		v = v.Index(0)
+	}
+	return v, true
+}
+
+// AssertRejectsMutations checks that valid passes validation and that every
+// mutation of it returned by Mutations fails on the mutated field.
+func AssertRejectsMutations(t *testing.T, valid any, opts ...validator.Option) {
+	t.Helper()
+	AssertValid(t, valid, opts...)
+	mutations, err := Mutations(valid, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mutations {
+		t.Run(m.Name, func(t *testing.T) {
+			AssertFieldError(t, validator.Validate(m.Value, opts...), m.Path, m.Rule)
+		})
+	}
+}
+
+// boundaryValues returns values of field's type that rule must reject.
+// Rules on a slice apply to its elements, except for required before dive.
+// Zero values are left out when omitEmpty would skip the rule for them.
+func boundaryValues(field reflect.Value, rule validator.Rule, dived, omitEmpty bool) []reflect.Value {
+	res := make([]reflect.Value, 0)
+	if field.Kind() == reflect.Slice && (dived || rule.Name != "required") {
+		for _, elem := range scalarBoundaryValues(field.Type().Elem(), rule) {
+			if omitEmpty && elem.IsZero() {
+				continue
+			}
+			bad := reflect.MakeSlice(field.Type(), 0, field.Len()+1)
+			bad = reflect.AppendSlice(bad, field)
+			res = append(res, reflect.Append(bad, elem))
+		}
+		return res
+	}
+	for _, bad := range scalarBoundaryValues(field.Type(), rule) {
+		if !omitEmpty || !bad.IsZero() {
+			res = append(res, bad)
+		}
+	}
+	return res
+}
+
+func scalarBoundaryValues(t reflect.Type, rule validator.Rule) []reflect.Value {
+	res := make([]reflect.Value, 0)
+	add := func(v any) {
+		res = append(res, reflect.ValueOf(v).Convert(t))
+	}
+	if rule.Name == "required" {
+		return append(res, reflect.Zero(t))
+	}
+	n, _ := strconv.Atoi(rule.Param)
+	switch t.Kind() {
+	case reflect.String:
+		switch rule.Name {
+		case "len":
+			if n > 0 {
+				add(strings.Repeat("a", n-1))
+			}
+			add(strings.Repeat("a", n+1))
+		case "min":
+			if n > 0 {
+				add(strings.Repeat("a", n-1))
+			}
+		case "max":
+			add(strings.Repeat("a", n+1))
+		case "in":
+			add(strings.ReplaceAll(rule.Param, ",", "") + "_")
+		case "email":
+			add("user.example.com")
+		case "nobidi":
+			add("user\u202egnp.exe")
+		case "noinvisible":
+			add("us\u200ber")
+		case "nocontrol":
+			add("user\x00")
+		case "singlescript":
+			add("p\u0430ypal")
+		case "filename":
+			add("../etc/passwd")
+			add("report.pdf.")
+			add("con.txt")
+			add("..%2fetc")
+			add("a\u2215b")
+		case "safepath":
+			add("../etc/passwd")
+			add("/etc/passwd")
+			add("a/../../b")
+			add("a\\..\\b")
+			if rule.Param != "" {
+				add(rule.Param + "_/a")
+			}
+		case "ext":
+			ext, _, _ := strings.Cut(rule.Param, ",")
+			add("file." + strings.TrimPrefix(ext, ".") + "_")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch rule.Name {
+		case "min":
+			add(n - 1)
+		case "max":
+			add(n + 1)
+		case "in":
+			maxAllowed := 0
+			for _, s := range strings.Split(rule.Param, ",") {
+				if v, err := strconv.Atoi(s); err == nil && v > maxAllowed {
+					maxAllowed = v
+				}
+			}
+			add(maxAllowed + 1)
+		}
+	}
+	return res
+}
+
+// AssertNoAllocs fails the test unless v is valid and Validate checks it
+// without allocating. v is boxed once by this call, so only the work done
+// by Validate itself is measured.
+func AssertNoAllocs(t testing.TB, v any) {
+	t.Helper()
+	AssertValid(t, v)
+	if allocs := testing.AllocsPerRun(100, func() { _ = validator.Validate(v) }); allocs != 0 {
+		t.Fatalf("Validate on valid %T allocated %v times per run", v, allocs)
+	}
+}
@@ -0,0 +1,179 @@
+package validatortest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/GeorgyMironov2001/validator"
+)
+
+// fakeTB records failures instead of stopping the test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Fatalf(format string, args ...any) {
+	t.failed = true
+	t.msg = fmt.Sprintf(format, args...)
+}
+
+type account struct {
+	Name  string `validate:"min:3"`
+	Inner inner
+}
+
+type inner struct {
+	Zip string `validate:"len:5"`
+}
+
+func TestAssertFieldError(t *testing.T) {
+	err := validator.Validate(account{Name: "ab", Inner: inner{Zip: "12345"}})
+	tests := []struct {
+		name       string
+		path, rule string
+		wantFail   bool
+	}{
+		{"matching rule", "Name", "min", false},
+		{"any rule", "Name", "", false},
+		{"other rule", "Name", "max", true},
+		{"other path", "Inner.Zip", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{}
+			AssertFieldError(tb, err, tt.path, tt.rule)
+			if tb.failed != tt.wantFail {
+				t.Fatalf("failed = %v, want %v (%s)", tb.failed, tt.wantFail, tb.msg)
+			}
+			tb = &fakeTB{}
+			AssertNoFieldError(tb, err, tt.path, tt.rule)
+			if tb.failed == tt.wantFail {
+				t.Fatalf("AssertNoFieldError failed = %v, want %v (%s)", tb.failed, !tt.wantFail, tb.msg)
+			}
+		})
+	}
+}
+
+func TestAssertFieldErrorNil(t *testing.T) {
+	tb := &fakeTB{}
+	AssertFieldError(tb, nil, "Name", "")
+	if !tb.failed {
+		t.Fatal("AssertFieldError passed on a nil error")
+	}
+	tb = &fakeTB{}
+	AssertNoFieldError(tb, nil, "Name", "")
+	if tb.failed {
+		t.Fatalf("AssertNoFieldError failed on a nil error: %s", tb.msg)
+	}
+}
+
+func TestAssertValid(t *testing.T) {
+	tb := &fakeTB{}
+	AssertValid(tb, account{Name: "abc", Inner: inner{Zip: "12345"}})
+	if tb.failed {
+		t.Fatalf("AssertValid failed on a valid value: %s", tb.msg)
+	}
+	AssertValid(tb, account{})
+	if !tb.failed {
+		t.Fatal("AssertValid passed on an invalid value")
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	err := validator.Validate(account{Name: "ab", Inner: inner{Zip: "1"}})
+	var paths []string
+	for _, fieldErr := range FieldErrors(err) {
+		paths = append(paths, fieldErr.Path())
+	}
+	if want := []string{"Name", "Inner.Zip"}; !slices.Equal(paths, want) {
+		t.Fatalf("got %q, want %q", paths, want)
+	}
+	if got := FieldErrors(nil); len(got) != 0 {
+		t.Fatalf("got %v for nil", got)
+	}
+}
+
+type everyRule struct {
+	Len      string   `validate:"len:3"`
+	In       string   `validate:"in:a,b"`
+	InInt    int      `validate:"in:1,2"`
+	Min      string   `validate:"min:2"`
+	MinInt   int      `validate:"min:10"`
+	Max      string   `validate:"max:4"`
+	MaxInt   int      `validate:"max:10"`
+	Required int      `validate:"required"`
+	Email    string   `validate:"email"`
+	Optional string   `validate:"omitempty|len:3"`
+	Dived    []string `validate:"required|dive|len:2"`
+	DivedInt []int    `validate:"dive|max:5"`
+	Bidi     string   `validate:"nobidi"`
+	Visible  string   `validate:"noinvisible"`
+	Control  string   `validate:"nocontrol"`
+	Script   string   `validate:"singlescript"`
+	Filename string   `validate:"filename"`
+	Path     string   `validate:"safepath:uploads"`
+	Ext      string   `validate:"ext:png"`
+	Items    []item
+}
+
+type item struct {
+	Qty int `validate:"min:1"`
+}
+
+func validEveryRule() everyRule {
+	return everyRule{
+		Len: "abc", In: "a", InInt: 1, Min: "ab", MinInt: 10, Max: "abcd", MaxInt: 10,
+		Required: 1, Email: "a@example.com", Dived: []string{"ab"}, DivedInt: []int{5},
+		Bidi: "a", Visible: "a", Control: "a", Script: "a", Filename: "a.txt",
+		Path: "uploads/a", Ext: "a.png", Items: []item{{Qty: 1}},
+	}
+}
+
+func TestMutationsCoverEveryRule(t *testing.T) {
+	valid := validEveryRule()
+	mutations, err := Mutations(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := make(map[string]bool)
+	for _, m := range mutations {
+		covered[m.Path+" "+m.Rule] = true
+		if fieldErr := findFieldError(validator.Validate(m.Value), m.Path, m.Rule); fieldErr == nil {
+			t.Errorf("%s: not rejected at %s by %s", m.Name, m.Path, m.Rule)
+		}
+	}
+	for _, want := range []string{
+		"Len len", "In in", "InInt in", "Min min", "MinInt min", "Max max", "MaxInt max",
+		"Required required", "Email email", "Optional len", "Dived required", "Dived[1] len",
+		"DivedInt[1] max", "Bidi nobidi", "Visible noinvisible", "Control nocontrol",
+		"Script singlescript", "Filename filename", "Path safepath", "Ext ext", "Items[0].Qty min",
+	} {
+		if !covered[want] {
+			t.Errorf("no mutation for %q", want)
+		}
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Fatalf("Mutations changed the fixture: %v", err)
+	}
+}
+
+func TestMutationsOmitEmpty(t *testing.T) {
+	mutations, err := Mutations(validEveryRule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mutations {
+		if m.Path == "Optional" && m.Value.(everyRule).Optional == "" {
+			t.Fatalf("%s: omitempty field set to its zero value", m.Name)
+		}
+	}
+}
+
+func TestAssertRejectsMutations(t *testing.T) {
+	AssertRejectsMutations(t, validEveryRule())
+}
@@ -0,0 +1,427 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidXML = errors.New("invalid XML")
+
+var xmlUnmarshalerType = reflect.TypeFor[xml.Unmarshaler]()
+
+// WithStrictXML makes DecodeXMLAndValidate report elements that do not
+// map to any struct field as ErrUnknownField.
+func WithStrictXML() Option {
+	return func(o *options) {
+		o.strictXML = true
+	}
+}
+
+// DecodeXMLAndValidate is the XML counterpart of DecodeAndValidate: it
+// decodes the XML document read from r into the struct v points to with
+// encoding/xml and validates it. Values that do not fit their field, and
+// with WithStrictXML elements that match no field, are reported next to
+// the failures found by Validate, and a malformed document as a single
+// ErrInvalidXML with the rule "invalid_xml".
+//
+// Paths are made of XML names rather than Go field names, with 1-based
+// indexes into repeated elements and attributes prefixed by "@", e.g.
+// "order/item[3]/@sku". Values that do not fit are decoded as empty, so
+// that the rest of the document is still decoded and validated.
+func DecodeXMLAndValidate(r io.Reader, v any, opts ...Option) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return NewValidationError(ErrNotStruct, "")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	o := newOptions(opts)
+	t := value.Type().Elem()
+	w := &xmlWalker{data: data, dec: xml.NewDecoder(bytes.NewReader(data)), strict: o.strictXML}
+	root, err := w.document(t)
+	if err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			err = fmt.Errorf("%w: %s on line %d", ErrInvalidXML, syntaxErr.Msg, syntaxErr.Line)
+		} else {
+			err = fmt.Errorf("%w: %s", ErrInvalidXML, err)
+		}
+		var resErrors Errors
+		resErrors.add(NewValidationError(err, ""), "", "invalid_xml")
+		return resErrors.result(o.order)
+	}
+	resErrors := w.resErrors
+	if err := xml.Unmarshal(w.blanked(), v); err != nil && len(resErrors) == 0 {
+		resErrors.add(NewValidationError(fmt.Errorf("%w: %s", ErrTypeMismatch, err), ""), root, "type_mismatch")
+	}
+	for _, err := range validateErrors(value.Elem(), &o) {
+		err.path = xmlPath(err.path, t, root)
+		if !resErrors.covers(err.path) {
+			resErrors = append(resErrors, err)
+		}
+	}
+	return resErrors.result(o.order)
+}
+
+// xmlField is a struct field as seen by encoding/xml.
+type xmlField struct {
+	// names are the element names in the field's tag, e.g. "items" and
+	// "item" for `xml:"items>item"`, or the attribute name.
+	names []string
+	attr  bool
+	// any and text are set for the ",any" field and for fields that take
+	// the element's character data, inner XML or comments.
+	any  bool
+	text bool
+	name string
+	// path is the dotted Go path to the field, which goes through the
+	// embedded structs it is promoted from.
+	path string
+	typ  reflect.Type
+}
+
+// xmlFields returns the fields encoding/xml decodes into for struct type t.
+func xmlFields(t reflect.Type) []xmlField {
+	res := make([]xmlField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("xml")
+		if tag == "-" || field.Name == "XMLName" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct {
+			for _, promoted := range xmlFields(fieldType) {
+				promoted.path = joinPath(field.Name, promoted.path)
+				res = append(res, promoted)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		res = append(res, xmlField{
+			names: strings.Split(name, ">"),
+			attr:  hasFlag(flags, "attr"),
+			any:   hasFlag(flags, "any"),
+			text:  hasFlag(flags, "chardata") || hasFlag(flags, "cdata") || hasFlag(flags, "innerxml") || hasFlag(flags, "comment"),
+			name:  field.Name,
+			path:  field.Name,
+			typ:   field.Type,
+		})
+	}
+	return res
+}
+
+func hasFlag(flags, flag string) bool {
+	return slices.Contains(strings.Split(flags, ","), flag)
+}
+
+// xmlPath translates the Go path of a value in a struct of type t decoded
+// from the root element into its XML path.
+func xmlPath(path string, t reflect.Type, root string) string {
+	res := root
+	segments := splitPath(path)
+	for len(segments) > 0 {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		segment := segments[0]
+		if strings.HasPrefix(segment, "[") {
+			if i, err := strconv.Atoi(strings.Trim(segment, "[]")); err == nil {
+				segment = "[" + strconv.Itoa(i+1) + "]"
+			}
+			res += segment
+			segments = segments[1:]
+			if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				t = t.Elem()
+			} else {
+				t = nil
+			}
+			continue
+		}
+		if field, n, ok := matchXMLField(segments, t); ok {
+			switch {
+			case field.attr:
+				res += "/@" + field.names[0]
+			case !field.text && !field.any:
+				res += "/" + strings.Join(field.names, "/")
+			}
+			segments = segments[n:]
+			t = field.typ
+			continue
+		}
+		res += "/" + segment
+		segments = segments[1:]
+		t = nil
+	}
+	return res
+}
+
+// matchXMLField finds the field of struct type t whose Go path makes up
+// the first n of segments.
+func matchXMLField(segments []string, t reflect.Type) (xmlField, int, bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return xmlField{}, 0, false
+	}
+	for _, field := range xmlFields(t) {
+		fieldPath := strings.Split(field.path, ".")
+		if len(fieldPath) <= len(segments) && slices.Equal(fieldPath, segments[:len(fieldPath)]) {
+			return field, len(fieldPath), true
+		}
+	}
+	return xmlField{}, 0, false
+}
+
+// xmlWalker reads an XML document along the struct type it is decoded
+// into and reports the values that do not fit.
+type xmlWalker struct {
+	data      []byte
+	dec       *xml.Decoder
+	strict    bool
+	resErrors Errors
+	// blanks are the byte ranges of data holding values that do not fit,
+	// in document order.
+	blanks [][2]int64
+}
+
+// blanked returns data without the values that do not fit, which
+// encoding/xml then decodes as zero instead of stopping at the first one.
+func (w *xmlWalker) blanked() []byte {
+	if len(w.blanks) == 0 {
+		return w.data
+	}
+	res := make([]byte, 0, len(w.data))
+	offset := int64(0)
+	for _, blank := range w.blanks {
+		res = append(res, w.data[offset:blank[0]]...)
+		offset = blank[1]
+	}
+	return append(res, w.data[offset:]...)
+}
+
+// document walks the whole document and returns the name of its root
+// element.
+func (w *xmlWalker) document(t reflect.Type) (string, error) {
+	for {
+		offset := w.dec.InputOffset()
+		tok, err := w.dec.Token()
+		if err == io.EOF {
+			return "", errors.New("no root element")
+		}
+		if err != nil {
+			return "", err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			if err := w.element(start, offset, t, start.Name.Local, start.Name.Local); err != nil {
+				return "", err
+			}
+			for {
+				if _, err := w.dec.Token(); err == io.EOF {
+					return start.Name.Local, nil
+				} else if err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+}
+
+// element walks the element started by start, read from offset, which is
+// decoded into a value of type t. path is the XML path of the element and
+// fieldName the name of the Go field it is decoded into.
+func (w *xmlWalker) element(start xml.StartElement, offset int64, t reflect.Type, path, fieldName string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if isXMLUnmarshaler(t) {
+		return w.dec.Skip()
+	}
+	if t.Kind() != reflect.Struct {
+		textStart := w.dec.InputOffset()
+		text, textEnd, err := w.text()
+		if err != nil {
+			return err
+		}
+		if !w.checkScalar(text, t, path, fieldName) {
+			w.blanks = append(w.blanks, [2]int64{textStart, textEnd})
+		}
+		return nil
+	}
+	fields := xmlFields(t)
+	values := attrValues(w.data[offset:w.dec.InputOffset()])
+	for i, attr := range start.Attr {
+		for _, field := range fields {
+			if field.attr && field.names[0] == attr.Name.Local {
+				if !w.checkScalar(attr.Value, field.typ, path+"/@"+attr.Name.Local, field.name) && i < len(values) {
+					w.blanks = append(w.blanks, [2]int64{offset + values[i][0], offset + values[i][1]})
+				}
+				break
+			}
+		}
+	}
+	return w.children(fields, nil, path)
+}
+
+// attrValues returns the byte ranges of the attribute values in tag, a
+// well-formed start tag, in the order they are written.
+func attrValues(tag []byte) [][2]int64 {
+	res := make([][2]int64, 0)
+	i := bytes.IndexAny(tag, " \t\r\n")
+	for i >= 0 && i < len(tag) {
+		eq := bytes.IndexByte(tag[i:], '=')
+		if eq < 0 {
+			break
+		}
+		i += eq + 1
+		quote := bytes.IndexAny(tag[i:], `"'`)
+		if quote < 0 {
+			break
+		}
+		start := i + quote + 1
+		end := bytes.IndexByte(tag[start:], tag[i+quote])
+		if end < 0 {
+			break
+		}
+		res = append(res, [2]int64{int64(start), int64(start + end)})
+		i = start + end + 1
+	}
+	return res
+}
+
+// children walks the child elements up to the end of the current element.
+// prefix holds the names already matched for fields tagged like "a>b".
+func (w *xmlWalker) children(fields []xmlField, prefix []string, path string) error {
+	counts := make(map[string]int)
+	for {
+		offset := w.dec.InputOffset()
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			name := tok.Name.Local
+			counts[name]++
+			childPath := path + "/" + name
+			names := append(prefix[:len(prefix):len(prefix)], name)
+			field, exact, ok := matchXMLElement(fields, names)
+			if !ok {
+				if w.strict && !slices.ContainsFunc(fields, func(f xmlField) bool { return f.any }) {
+					if counts[name] > 1 {
+						childPath = fmt.Sprintf("%s[%d]", childPath, counts[name])
+					}
+					w.resErrors.add(NewValidationError(ErrUnknownField, name), childPath, "unknown_field")
+				}
+				if err := w.dec.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if !exact {
+				if err := w.children(fields, names, childPath); err != nil {
+					return err
+				}
+				continue
+			}
+			fieldType := field.typ
+			if fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() != reflect.Uint8 {
+				fieldType = fieldType.Elem()
+				childPath = fmt.Sprintf("%s[%d]", childPath, counts[name])
+			}
+			if err := w.element(tok, offset, fieldType, childPath, field.name); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// matchXMLElement finds the element field whose names are, or start with,
+// names.
+func matchXMLElement(fields []xmlField, names []string) (field xmlField, exact, ok bool) {
+	for _, field := range fields {
+		if field.attr || field.text || field.any || len(field.names) < len(names) || !slices.Equal(field.names[:len(names)], names) {
+			continue
+		}
+		return field, len(field.names) == len(names), true
+	}
+	return xmlField{}, false, false
+}
+
+// text returns the character data up to the end of the current element,
+// and the offset of its end tag.
+func (w *xmlWalker) text() (string, int64, error) {
+	var b strings.Builder
+	for {
+		offset := w.dec.InputOffset()
+		tok, err := w.dec.Token()
+		if err != nil {
+			return "", 0, err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b.Write(tok)
+		case xml.StartElement:
+			if err := w.dec.Skip(); err != nil {
+				return "", 0, err
+			}
+		case xml.EndElement:
+			return b.String(), offset, nil
+		}
+	}
+}
+
+// checkScalar reports text that encoding/xml cannot decode into type t
+// and returns whether it can.
+func (w *xmlWalker) checkScalar(text string, t reflect.Type, path, fieldName string) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if isXMLUnmarshaler(t) {
+		return true
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return true
+	}
+	var err error
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(text, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(text, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(text, t.Bits())
+	case reflect.Bool:
+		_, err = strconv.ParseBool(text)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: expected %s, got %q", ErrTypeMismatch, t.Kind(), text)
+		w.resErrors.add(NewValidationError(err, fieldName), path, "type_mismatch")
+		return false
+	}
+	return true
+}
+
+func isXMLUnmarshaler(t reflect.Type) bool {
+	return t.Implements(xmlUnmarshalerType) || reflect.PointerTo(t).Implements(xmlUnmarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
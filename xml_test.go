@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type xmlItem struct {
+	SKU   string `xml:"sku,attr" validate:"len:4"`
+	Count int    `xml:"count,attr" validate:"min:1"`
+	Qty   int    `xml:"qty" validate:"min:1|max:10"`
+	Note  string `xml:",chardata"`
+}
+
+type xmlOrder struct {
+	XMLName xml.Name  `xml:"order"`
+	ID      uint      `xml:"id,attr"`
+	Items   []xmlItem `xml:"items>item"`
+	Email   string    `xml:"customer>email" validate:"email"`
+	Express bool      `xml:"express"`
+}
+
+func TestDecodeXMLAndValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		strict bool
+		want   string
+	}{
+		{
+			name: "valid",
+			doc: `<order id="7"><items><item sku="ab12" count="1"><qty>2</qty></item></items>` +
+				`<customer><email>a@example.com</email></customer><express>true</express></order>`,
+		},
+		{
+			name: "rules",
+			doc: `<order><items><item sku="ab12" count="1"><qty>2</qty></item><item sku="x" count="0"><qty>20</qty></item></items>` +
+				`<customer><email>nope</email></customer></order>`,
+			want: "order/items/item[2]/@sku: len validation failed\n" +
+				"order/items/item[2]/@count: min validation failed\n" +
+				"order/items/item[2]/qty: max validation failed\n" +
+				"order/customer/email: email validation failed",
+		},
+		{
+			name: "every type mismatch",
+			doc: `<order id="-1"><items><item sku="x" count='many'><qty>lots</qty></item>` +
+				`<item sku="ab12" count="1"><qty> 3 </qty></item></items>` +
+				`<customer><email>nope</email></customer><express>maybe</express></order>`,
+			want: "order/@id: type mismatch: expected uint, got \"-1\"\n" +
+				"order/items/item[1]/@count: type mismatch: expected int, got \"many\"\n" +
+				"order/items/item[1]/qty: type mismatch: expected int, got \"lots\"\n" +
+				"order/express: type mismatch: expected bool, got \"maybe\"\n" +
+				"order/items/item[1]/@sku: len validation failed\n" +
+				"order/customer/email: email validation failed",
+		},
+		{
+			name:   "strict",
+			doc:    `<order><nick>al</nick><items><item sku="ab12" count="1"><qty>1</qty><x/><x/></item></items><customer><email>a@example.com</email></customer></order>`,
+			strict: true,
+			want: "order/nick: unknown field\n" +
+				"order/items/item[1]/x: unknown field\n" +
+				"order/items/item[1]/x[2]: unknown field",
+		},
+		{
+			name: "malformed",
+			doc:  "<order>\n<items></order>",
+			want: ": invalid XML: element <items> closed by </order> on line 2",
+		},
+		{
+			name: "empty",
+			doc:  "  ",
+			want: ": invalid XML: no root element",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.strict {
+				opts = append(opts, WithStrictXML())
+			}
+			var v xmlOrder
+			err := DecodeXMLAndValidate(strings.NewReader(tt.doc), &v, opts...)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got:\n%v\nwant:\n%s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeXMLAndValidateDecodesPastMismatch(t *testing.T) {
+	doc := `<order id="x"><items><item sku="ab12" count="1"><qty>no</qty></item><item sku="cd34" count="2"><qty>3</qty></item></items>` +
+		`<customer><email>a@example.com</email></customer><express>1</express></order>`
+	var v xmlOrder
+	err := DecodeXMLAndValidate(strings.NewReader(doc), &v)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("got %v", err)
+	}
+	if v.ID != 0 || len(v.Items) != 2 || v.Items[0].Qty != 0 || v.Items[1].SKU != "cd34" || v.Items[1].Qty != 3 ||
+		v.Email != "a@example.com" || !v.Express {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestAttrValues(t *testing.T) {
+	tag := []byte(`<item a="1" b = 'x"y' c="">`)
+	want := [][2]int64{{9, 10}, {17, 20}, {25, 25}}
+	got := attrValues(tag)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}